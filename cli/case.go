@@ -0,0 +1,143 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// translitTable contains ASCII transliteration for non-ASCII letters
+var translitTable = map[rune]string{
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "",
+	'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya", 'і': "i", 'ї': "yi",
+	'є': "ye", 'ґ': "g", 'ў': "u",
+
+	// Latin
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c", 'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ì': "i", 'í': "i",
+	'î': "i", 'ï': "i", 'ð': "d", 'ñ': "n", 'ò': "o", 'ó': "o", 'ô': "o",
+	'õ': "o", 'ö': "o", 'ø': "o", 'ù': "u", 'ú': "u", 'û': "u", 'ü': "u",
+	'ý': "y", 'ÿ': "y", 'þ': "th", 'ß': "ss", 'ą': "a", 'ć': "c", 'č': "c",
+	'ď': "d", 'ę': "e", 'ě': "e", 'ğ': "g", 'ı': "i", 'ł': "l", 'ń': "n",
+	'ň': "n", 'ő': "o", 'œ': "oe", 'ř': "r", 'ś': "s", 'š': "s", 'ş': "s",
+	'ť': "t", 'ů': "u", 'ű': "u", 'ź': "z", 'ż': "z", 'ž': "z",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// convertStem converts stem of the last path element using given function
+func convertStem(data string, fn func(words []string) string) string {
+	dir, base, trailing := splitPath(data)
+	stem, ext := splitExt(base)
+
+	// Leading dots of dotfiles are kept as is
+	name := strings.TrimLeft(stem, ".")
+	words := splitWords(name)
+
+	if len(words) == 0 {
+		return data
+	}
+
+	return dir + stem[:len(stem)-len(name)] + fn(words) + ext + trailing
+}
+
+// splitWords splits string into words using separators, case changes
+// (fooBar, HTTPServer) and transitions from digits to upper case letters
+func splitWords(data string) []string {
+	var result []string
+	var word []rune
+
+	runes := []rune(data)
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) != 0 {
+				result = append(result, string(word))
+				word = nil
+			}
+
+			continue
+		}
+
+		if len(word) != 0 && unicode.IsUpper(r) {
+			prev := word[len(word)-1]
+			isAcronymEnd := unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if !unicode.IsUpper(prev) || isAcronymEnd {
+				result = append(result, string(word))
+				word = nil
+			}
+		}
+
+		word = append(word, r)
+	}
+
+	if len(word) != 0 {
+		result = append(result, string(word))
+	}
+
+	return result
+}
+
+// joinWords joins words in lower case using given separator
+func joinWords(words []string, sep string) string {
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+
+	return strings.Join(words, sep)
+}
+
+// joinTitleWords joins capitalized words using given separator
+func joinTitleWords(words []string, sep string) string {
+	for i, word := range words {
+		words[i] = capitalize(word)
+	}
+
+	return strings.Join(words, sep)
+}
+
+// capitalize converts the first letter of word to upper case and all other
+// letters to lower case
+func capitalize(word string) string {
+	runes := []rune(strings.ToLower(word))
+	runes[0] = unicode.ToUpper(runes[0])
+
+	return string(runes)
+}
+
+// transliterate converts string to ASCII, all unknown non-ASCII symbols are removed
+func transliterate(data string) string {
+	var buf strings.Builder
+
+	for _, r := range data {
+		lr := unicode.ToLower(r)
+
+		switch {
+		case r < unicode.MaxASCII:
+			buf.WriteRune(r)
+		case translitTable[lr] != "":
+			if unicode.IsUpper(r) {
+				buf.WriteString(capitalize(translitTable[lr]))
+			} else {
+				buf.WriteString(translitTable[lr])
+			}
+		}
+	}
+
+	return buf.String()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
@@ -18,6 +18,7 @@ import (
 	"github.com/essentialkaos/ek/v13/fmtc"
 	"github.com/essentialkaos/ek/v13/fsutil"
 	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/strutil"
 	"github.com/essentialkaos/ek/v13/support"
 	"github.com/essentialkaos/ek/v13/support/deps"
 	"github.com/essentialkaos/ek/v13/terminal"
@@ -44,12 +45,24 @@ const (
 const (
 	OPT_ZERO     = "z:zero"
 	OPT_SPACE    = "s:space"
+	OPT_INPUT    = "i:input"
+	OPT_PAIR     = "P:pair"
 	OPT_QUIET    = "q:quiet"
+	OPT_ERRORS   = "E:errors"
+	OPT_STRICT   = "strict"
+	OPT_TRACE    = "trace"
+	OPT_SAFE     = "safe-output"
+	OPT_NO_SAFE  = "no-safe-output"
+	OPT_MODE     = "M:mode"
+	OPT_STYLE    = "style"
+	OPT_FULL     = "F:full"
+	OPT_SERVE    = "S:serve"
 	OPT_NO_COLOR = "nc:no-color"
 	OPT_HELP     = "h:help"
 	OPT_VER      = "v:version"
 
 	OPT_UPDATE       = "U:update"
+	OPT_INTERACTIVE  = "I:interactive"
 	OPT_VERB_VER     = "vv:verbose-version"
 	OPT_COMPLETION   = "completion"
 	OPT_GENERATE_MAN = "generate-man"
@@ -64,27 +77,84 @@ const (
 	CMD_READLINK    = "link"
 	CMD_CLEAN       = "clean"
 	CMD_COMPACT     = "compact"
+	CMD_TRUNCATE    = "truncate"
 	CMD_EXT         = "ext"
+	CMD_STEM        = "stem"
 	CMD_ABS         = "abs"
+	CMD_EXPAND      = "expand"
+	CMD_TILDE       = "tilde"
 	CMD_MATCH       = "match"
 	CMD_JOIN        = "join"
+	CMD_SPLIT       = "split"
+	CMD_ELEM        = "elem"
+	CMD_SLICE       = "slice"
+	CMD_DEPTH       = "depth"
+	CMD_REL         = "rel"
+	CMD_REL_REAL    = "rel-real"
+	CMD_REL_UNDER   = "rel-under"
 
 	CMD_ADD_PREFIX = "add-prefix"
 	CMD_DEL_PREFIX = "del-prefix"
 	CMD_ADD_SUFFIX = "add-suffix"
 	CMD_DEL_SUFFIX = "del-suffix"
 	CMD_STRIP_EXT  = "strip-ext"
+	CMD_SET_EXT    = "set-ext"
+	CMD_SET_BASE   = "set-base"
+	CMD_SET_DIR    = "set-dir"
 	CMD_EXCLUDE    = "exclude"
 	CMD_REPLACE    = "replace"
 	CMD_LOWER      = "lower"
 	CMD_UPPER      = "upper"
+	CMD_SNAKE      = "snake"
+	CMD_KEBAB      = "kebab"
+	CMD_CAMEL      = "camel"
+	CMD_PASCAL     = "pascal"
+	CMD_TITLE      = "title"
+	CMD_SLUG       = "slug"
+	CMD_NFC        = "nfc"
+	CMD_NFD        = "nfd"
+	CMD_NFKC       = "nfkc"
+	CMD_FOLD       = "fold"
+	CMD_SANITIZE   = "sanitize"
+	CMD_QUOTE      = "quote"
+	CMD_UNQUOTE    = "unquote"
+	CMD_ESCAPE     = "escape"
+	CMD_UNESCAPE   = "unescape"
+	CMD_TO_URI     = "to-uri"
+	CMD_FROM_URI   = "from-uri"
+	CMD_URL_ENCODE = "url-encode"
+	CMD_URL_DECODE = "url-decode"
+	CMD_TO_POSIX   = "to-posix"
+	CMD_TO_WINDOWS = "to-windows"
+	CMD_TO_WSL     = "to-wsl"
+	CMD_TO_CYGWIN  = "to-cygwin"
+	CMD_EACH       = "each"
+	CMD_EACH_DIR   = "each-dir"
+	CMD_EACH_BASE  = "each-base"
+
+	CMD_MAX_LEN      = "max-len"
+	CMD_MAX_NAME_LEN = "max-name-len"
+	CMD_MAX_DEPTH    = "max-depth"
 
 	CMD_IS_ABS   = "is-abs"
 	CMD_IS_LOCAL = "is-local"
 	CMD_IS_SAFE  = "is-safe"
 	CMD_IS_MATCH = "is-match"
+
+	CMD_IS_NORMALIZED = "is-normalized"
+	CMD_IS_PORTABLE   = "is-portable"
+	CMD_IS_WITHIN     = "is-within-limits"
+
+	CMD_SAME_FILE = "same-file"
+	CMD_COMMON    = "common"
+	CMD_IS_UNDER  = "is-under"
+
+	CMD_HELP = "help"
 )
 
+// RECORDS_SEP is separator used for returning many records from one handler
+const RECORDS_SEP = "\x00"
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // handlerFunc is a function for processing command data
@@ -94,6 +164,7 @@ type handlerFunc func(data string, args options.Arguments) (string, error, bool)
 type handler struct {
 	Func handlerFunc       // Handler function
 	Args options.Arguments // Command arguments
+	Name string            // Command name
 }
 
 // pipe is a slice of handler to process data
@@ -105,7 +176,18 @@ type pipe []*handler
 var optMap = options.Map{
 	OPT_ZERO:     {Type: options.BOOL},
 	OPT_SPACE:    {Type: options.BOOL},
+	OPT_INPUT:    {Mergeble: true},
+	OPT_PAIR:     {Type: options.BOOL},
 	OPT_QUIET:    {Type: options.BOOL},
+	OPT_ERRORS:   {},
+	OPT_STRICT:   {Type: options.BOOL},
+	OPT_TRACE:    {Type: options.BOOL},
+	OPT_SAFE:     {Type: options.BOOL},
+	OPT_NO_SAFE:  {Type: options.BOOL, Conflicts: OPT_SAFE},
+	OPT_MODE:     {},
+	OPT_STYLE:    {},
+	OPT_FULL:     {Type: options.BOOL},
+	OPT_SERVE:    {Type: options.MIXED},
 	OPT_NO_COLOR: {Type: options.BOOL},
 	OPT_HELP:     {Type: options.BOOL},
 	OPT_VER:      {Type: options.MIXED},
@@ -127,6 +209,27 @@ var colorTagVer string
 // separator is data separator
 var separator string
 
+// strictMode is strict mode flag
+var strictMode bool
+
+// fullExtMode is flag for using compound extensions
+var fullExtMode bool
+
+// pathMode is mode of records processing (path, url or auto)
+var pathMode string
+
+// pathStyle is style of paths (posix or windows)
+var pathStyle string
+
+// safeOutput is flag for escaping non-printable symbols in output
+var safeOutput bool
+
+// traceMode is flag for printing info about failed checks
+var traceMode bool
+
+// pairMode is pair mode flag
+var pairMode bool
+
 // hasStdinData is marker that shows that there some data in stdin
 var hasStdinData bool
 
@@ -135,15 +238,78 @@ var hasStdinData bool
 // minCmdArgs contains minimum number of arguments
 var minCmdArgs = map[string]int{
 	CMD_DIRNAME_NUM: 1,
+	CMD_TRUNCATE:    1,
 	CMD_MATCH:       1,
 	CMD_JOIN:        1,
+	CMD_ELEM:        1,
+	CMD_SLICE:       1,
+	CMD_REL:         1,
+	CMD_REL_REAL:    1,
+	CMD_REL_UNDER:   1,
 	CMD_ADD_PREFIX:  1,
 	CMD_DEL_PREFIX:  1,
 	CMD_ADD_SUFFIX:  1,
 	CMD_DEL_SUFFIX:  1,
 	CMD_EXCLUDE:     1,
 	CMD_REPLACE:     2,
+	CMD_SET_EXT:     1,
+	CMD_SET_BASE:    1,
+	CMD_SET_DIR:     1,
+	CMD_SANITIZE:    1,
+	CMD_QUOTE:       1,
+	CMD_EACH:        1,
+	CMD_EACH_DIR:    1,
+	CMD_EACH_BASE:   1,
 	CMD_IS_MATCH:    1,
+
+	CMD_MAX_LEN:      1,
+	CMD_MAX_NAME_LEN: 1,
+	CMD_MAX_DEPTH:    1,
+
+	CMD_IS_NORMALIZED: 1,
+	CMD_IS_PORTABLE:   1,
+	CMD_IS_WITHIN:     1,
+}
+
+// optCmdArgs contains maximum number of optional arguments which can be passed
+// to command only using pipeline syntax (e.g. ext+2)
+var optCmdArgs = map[string]int{
+	CMD_EXT:     1,
+	CMD_UNQUOTE: 1,
+	CMD_COMPACT: 4,
+}
+
+// optCmdArgsValidators contains validators for optional arguments passed using
+// pipeline syntax
+var optCmdArgsValidators = map[string]func(args options.Arguments) error{
+	CMD_EXT:     validateExtSegments,
+	CMD_UNQUOTE: validateQuoteStyle,
+	CMD_COMPACT: validateCompactOptions,
+}
+
+// multiCommands contains commands which return many records separated by
+// RECORDS_SEP
+var multiCommands = map[string]bool{
+	CMD_SPLIT: true,
+}
+
+// cmdArgsValidators contains validators for required command arguments
+var cmdArgsValidators = map[string]func(args options.Arguments) error{
+	CMD_DIRNAME_NUM: validateDirNum,
+	CMD_MATCH:       validatePattern,
+	CMD_IS_MATCH:    validatePattern,
+	CMD_ELEM:        validateIndex,
+	CMD_SLICE:       validateSlice,
+	CMD_TRUNCATE:    validateWidth,
+
+	CMD_IS_NORMALIZED: validateNormForm,
+	CMD_SANITIZE:      validateProfile,
+	CMD_IS_PORTABLE:   validateProfile,
+	CMD_MAX_LEN:       validateLimit,
+	CMD_MAX_NAME_LEN:  validateLimit,
+	CMD_MAX_DEPTH:     validateLimit,
+	CMD_IS_WITHIN:     validateLimitsProfile,
+	CMD_QUOTE:         validateQuoteStyle,
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -160,11 +326,28 @@ func Run(gitRev string, gomod []byte) {
 	if !errs.IsEmpty() {
 		terminal.Error("Options parsing errors:")
 		terminal.Error(errs.Error(" - "))
-		os.Exit(1)
+		os.Exit(EC_USAGE)
 	}
 
 	configureUI()
 
+	errorsFormat := strutil.Q(options.GetS(OPT_ERRORS), ERRORS_TEXT)
+
+	if errorsFormat != ERRORS_TEXT && errorsFormat != ERRORS_JSON {
+		terminal.Error("Unsupported errors format %q", errorsFormat)
+		os.Exit(EC_USAGE)
+	}
+
+	if pathMode != MODE_PATH && pathMode != MODE_URL && pathMode != MODE_AUTO {
+		terminal.Error("Unsupported mode %q", pathMode)
+		os.Exit(EC_USAGE)
+	}
+
+	if pathStyle != STYLE_POSIX && pathStyle != STYLE_WINDOWS {
+		terminal.Error("Unsupported path style %q", pathStyle)
+		os.Exit(EC_USAGE)
+	}
+
 	switch {
 	case options.Has(OPT_COMPLETION):
 		os.Exit(printCompletion())
@@ -182,19 +365,28 @@ func Run(gitRev string, gomod []byte) {
 		os.Exit(0)
 	case withSelfUpdate && options.GetB(OPT_UPDATE):
 		os.Exit(updateBinary())
-	case options.GetB(OPT_HELP) || len(args) == 0:
-		genUsage().Print()
+	case withInteractive && options.GetB(OPT_INTERACTIVE):
+		os.Exit(startInteractive(args))
+	case args.Get(0).String() == CMD_HELP && args.Has(1):
+		os.Exit(printCommandHelp(args.Get(1).String()))
+	case options.Has(OPT_SERVE):
+		os.Exit(startServer(options.GetS(OPT_SERVE)))
+	case options.GetB(OPT_HELP) || len(args) == 0 || args.Get(0).String() == CMD_HELP:
+		addPluginsUsage(genUsage()).Print()
 		os.Exit(0)
 	}
 
 	err, ok := runCommands(args)
 
+	stopPlugins()
+
 	if err != nil {
-		printError(err.Error())
+		printAppError(err, errorsFormat)
+		os.Exit(getExitCode(err))
 	}
 
 	if !ok {
-		os.Exit(1)
+		os.Exit(EC_FALSE)
 	}
 }
 
@@ -216,7 +408,12 @@ func preConfigureUI() {
 
 // preConfigureOptions preconfigures command-line options based on build tags
 func preConfigureOptions() {
+	// Values of repeated --input options are joined using NUL instead of space,
+	// because file names can contain spaces
+	options.MergeSymbol = "\x00"
+
 	optMap.SetIf(withSelfUpdate, OPT_UPDATE, &options.V{Type: options.MIXED})
+	optMap.SetIf(withInteractive, OPT_INTERACTIVE, &options.V{Type: options.BOOL})
 }
 
 // configureUI configures user interface
@@ -226,6 +423,15 @@ func configureUI() {
 	}
 
 	quietMode = options.GetB(OPT_QUIET) || os.Getenv("PATH_QUIET") != ""
+	strictMode = options.GetB(OPT_STRICT)
+	traceMode = options.GetB(OPT_TRACE)
+	safeOutput = options.GetB(OPT_SAFE) || (tty.IsTTY() && !options.GetB(OPT_NO_SAFE))
+	pathMode = strutil.Q(options.GetS(OPT_MODE), MODE_PATH)
+	pathStyle = strutil.Q(options.GetS(OPT_STYLE), STYLE_POSIX)
+	pairMode = options.GetB(OPT_PAIR)
+	fullExtMode = options.GetB(OPT_FULL)
+
+	loadCompoundExts()
 
 	switch {
 	case options.GetB(OPT_SPACE):
@@ -250,7 +456,7 @@ func runCommands(args options.Arguments) (error, bool) {
 
 	cmd := args.Get(0).String()
 
-	if strings.ContainsRune(cmd, ',') {
+	if strings.ContainsAny(cmd, ",+") {
 		cmds, err = parseCommandPipe(cmd)
 		data = args[1:].Strings()
 	} else {
@@ -262,8 +468,10 @@ func runCommands(args options.Arguments) (error, bool) {
 		return err, false
 	}
 
-	if !hasStdinData && len(data) == 0 {
-		return fmt.Errorf("There is no data for command"), false
+	inputs := options.Split(OPT_INPUT)
+
+	if !hasStdinData && len(data) == 0 && len(inputs) == 0 {
+		return usageError("There is no data for command"), false
 	}
 
 	if len(data) > 0 {
@@ -274,7 +482,21 @@ func runCommands(args options.Arguments) (error, bool) {
 		}
 	}
 
-	if hasStdinData {
+	if pairMode && len(inputs) == 2 {
+		err, ok := processZippedData(cmds, inputs[0], inputs[1])
+
+		if err != nil || !ok {
+			return err, false
+		}
+	} else if len(inputs) > 0 {
+		for _, file := range inputs {
+			err, ok := processFileData(cmds, file)
+
+			if err != nil || !ok {
+				return err, false
+			}
+		}
+	} else if hasStdinData {
 		err, ok := processStdinData(cmds)
 
 		if err != nil || !ok {
@@ -287,44 +509,141 @@ func runCommands(args options.Arguments) (error, bool) {
 
 // processArgsData runs commands over data passed as CLI arguments
 func processArgsData(cmds pipe, data []string) (error, bool) {
-	for _, str := range data {
-		err, ok := executePipeHandlers(cmds, str)
+	for i := 0; i < len(data); i++ {
+		var err error
+		var ok bool
+
+		str := data[i]
+
+		if len(str) > 1 && str[0] == '@' && str[1] != '@' {
+			if !fsutil.IsExist(str[1:]) {
+				return ioError(
+					"List file %q doesn't exist (use @@ prefix for data which starts with @)",
+					str[1:],
+				), false
+			}
 
-		if err != nil || !ok {
-			return err, false
+			err, ok = processFileData(cmds, str[1:])
+
+			if err != nil {
+				return err, false
+			}
+		} else {
+			// Number of the first argument of record (pair can use two arguments)
+			num := i + 1
+			str = unescapeArg(str)
+
+			if pairMode {
+				switch {
+				case strings.Contains(str, "\t"):
+					str, err = parsePair(str)
+				case i+1 < len(data):
+					i++
+					str = makePair(str, unescapeArg(data[i]))
+				default:
+					err = fmt.Errorf("Argument doesn't have a pair")
+				}
+
+				if err != nil {
+					return sourceError(err, "argument", num), false
+				}
+			}
+
+			err, ok = processData(cmds, str)
+
+			if err != nil {
+				return sourceError(err, "argument", num), false
+			}
+		}
+
+		if !ok {
+			return nil, false
 		}
 	}
 
 	return nil, true
 }
 
+// unescapeArg removes escaping from argument which starts with @
+func unescapeArg(arg string) string {
+	// Double @ is used for escaping data which starts with @
+	if strings.HasPrefix(arg, "@@") {
+		return arg[1:]
+	}
+
+	return arg
+}
+
+// processFileData runs commands over data from given file
+func processFileData(cmds pipe, file string) (error, bool) {
+	if file == "-" {
+		return processStdinData(cmds)
+	}
+
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return ioError("Can't open input file: %v", err), false
+	}
+
+	defer fd.Close()
+
+	return processReaderData(cmds, fd, file)
+}
+
 // processStdinData runs commands over data passed via standard input
 func processStdinData(cmds pipe) (error, bool) {
-	r := bufio.NewReader(os.Stdin)
-	delim := separator[0]
+	return processReaderData(cmds, os.Stdin, "stdin")
+}
 
-	for {
-		str, err := r.ReadString(delim)
+// processReaderData runs commands over data from given reader
+func processReaderData(cmds pipe, rr io.Reader, source string) (error, bool) {
+	r := bufio.NewReader(rr)
+
+	for num := 1; ; num++ {
+		str, err := readRecord(r)
+
+		if err == io.EOF {
+			break
+		}
 
 		if err != nil {
-			if err == io.EOF {
-				break
-			} else {
-				return fmt.Errorf("Can't read stdin data: %v", err), false
+			return ioError("Can't read data from %s: %v", source, err), false
+		}
+
+		if pairMode {
+			str, err = readPair(r, str)
+
+			if err != nil {
+				return sourceError(err, source, num), false
 			}
 		}
 
-		str = strings.TrimRight(str, separator)
-		err, ok := executePipeHandlers(cmds, str)
+		err, ok := processData(cmds, str)
 
-		if err != nil || !ok {
-			return err, false
+		if err != nil {
+			return sourceError(err, source, num), false
+		}
+
+		if !ok {
+			return nil, false
 		}
 	}
 
 	return nil, true
 }
 
+// readRecord reads one record from reader
+func readRecord(r *bufio.Reader) (string, error) {
+	str, err := r.ReadString(separator[0])
+
+	if err != nil && (err != io.EOF || str == "") {
+		return "", err
+	}
+
+	return strings.TrimRight(str, separator), nil
+}
+
 // parseCommandPipe parses command pipe
 func parseCommandPipe(data string) (pipe, error) {
 	var result pipe
@@ -338,121 +657,441 @@ func parseCommandPipe(data string) (pipe, error) {
 			args = options.NewArguments(strings.Split(rawArgs, "+")...)
 		}
 
-		h, _, err := createCommandHandler(cmd, args)
+		h, extra, err := createCommandHandler(cmd, args)
 
 		if err != nil {
 			return nil, err
 		}
 
+		// Arguments which are not used by command are passed to the handler
+		// only if command is a plugin or has optional arguments
+		if len(extra) != 0 {
+			err = checkExtraArgs(cmd, args.Strings(), extra)
+
+			if err != nil {
+				return nil, err
+			}
+
+			h.Args = options.NewArguments(extra...)
+		}
+
 		result = append(result, h)
 	}
 
 	return result, nil
 }
 
+// checkExtraArgs checks that command from pipeline accepts arguments which are
+// not used by command handler
+func checkExtraArgs(cmd string, args, extra []string) error {
+	name := getCommandName(getArgsTarget(cmd, args))
+
+	// Plugins accept any number of arguments
+	if getCommandInfo(name) == nil {
+		return nil
+	}
+
+	if len(extra) > optCmdArgs[name] {
+		return usageError("Too many arguments for command %q", name)
+	}
+
+	if len(extra) != 0 && optCmdArgsValidators[name] != nil {
+		err := optCmdArgsValidators[name](options.NewArguments(extra...))
+
+		if err != nil {
+			return usageError("Command %q: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// getArgsTarget returns name of command which receives extra arguments. For each
+// commands and scope modifiers it is a nested command.
+func getArgsTarget(cmd string, args []string) string {
+	for {
+		_, isEach := eachTargets[getCommandName(cmd)]
+
+		switch {
+		case isScopedCommand(cmd):
+			_, cmd, _ = strings.Cut(cmd, ":")
+
+			if cmd == "" && len(args) != 0 {
+				cmd, args = args[0], args[1:]
+			}
+		case isEach && len(args) != 0:
+			cmd, args = args[0], args[1:]
+		default:
+			return cmd
+		}
+	}
+}
+
 // createCommandHandler returns handler for command
 func createCommandHandler(cmd string, args options.Arguments) (*handler, []string, error) {
-	cmd = strings.ToLower(cmd)
+	if isScopedCommand(cmd) {
+		return newScopeHandler(cmd, args)
+	}
+
+	cmd = getCommandName(cmd)
 	minArgs := minCmdArgs[cmd]
 
+	if pairMode && pairCommands[cmd] {
+		minArgs = 0
+	}
+
 	if minArgs > 0 && len(args) < minArgs {
-		return nil, nil, fmt.Errorf("Not enough arguments for command %q", cmd)
+		return nil, nil, usageError("Not enough arguments for command %q", cmd)
+	}
+
+	if pathStyle == STYLE_WINDOWS && winUnsupportedCommands[cmd] {
+		return nil, nil, usageError("Command %q doesn't support Windows path style", cmd)
 	}
 
-	switch strings.ToLower(cmd) {
-	case CMD_BASENAME, "basename":
-		return &handler{cmdBasename, nil}, args.Strings(), nil
+	if minArgs > 0 && cmdArgsValidators[cmd] != nil {
+		err := cmdArgsValidators[cmd](args[:minArgs])
+
+		if err != nil {
+			return nil, nil, usageError("Command %q: %v", cmd, err)
+		}
+	}
 
-	case CMD_DIRNAME, "dirname":
-		return &handler{cmdDirname, nil}, args.Strings(), nil
+	switch cmd {
+	case CMD_BASENAME:
+		return &handler{cmdBasename, nil, cmd}, args.Strings(), nil
+
+	case CMD_DIRNAME:
+		return &handler{cmdDirname, nil, cmd}, args.Strings(), nil
 
 	case CMD_DIRNAME_NUM:
-		return &handler{cmdDirnameNum, args[:minArgs]}, args[minArgs:].Strings(), nil
+		return &handler{cmdDirnameNum, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
 
-	case CMD_READLINK, "readlink":
-		return &handler{cmdReadlink, nil}, args.Strings(), nil
+	case CMD_READLINK:
+		return &handler{cmdReadlink, nil, cmd}, args.Strings(), nil
 
 	case CMD_CLEAN:
-		return &handler{cmdClean, nil}, args.Strings(), nil
+		return &handler{cmdClean, nil, cmd}, args.Strings(), nil
 
 	case CMD_COMPACT:
-		return &handler{cmdCompact, nil}, args.Strings(), nil
+		return &handler{cmdCompact, nil, cmd}, args.Strings(), nil
+
+	case CMD_STEM:
+		return &handler{cmdStem, nil, cmd}, args.Strings(), nil
 
 	case CMD_ABS:
-		return &handler{cmdAbs, nil}, args.Strings(), nil
+		return &handler{cmdAbs, nil, cmd}, args.Strings(), nil
+
+	case CMD_EXPAND:
+		return &handler{cmdExpand, nil, cmd}, args.Strings(), nil
+
+	case CMD_TILDE:
+		return &handler{cmdTilde, nil, cmd}, args.Strings(), nil
 
 	case CMD_EXT:
-		return &handler{cmdExt, nil}, args.Strings(), nil
+		return &handler{cmdExt, nil, cmd}, args.Strings(), nil
 
 	case CMD_MATCH:
-		return &handler{cmdMatch, args[:minArgs]}, args[minArgs:].Strings(), nil
+		return &handler{cmdMatch, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
 
 	case CMD_JOIN:
-		return &handler{cmdJoin, args[:minArgs]}, args[minArgs:].Strings(), nil
+		return &handler{cmdJoin, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_SPLIT:
+		return &handler{cmdSplit, nil, cmd}, args.Strings(), nil
+
+	case CMD_ELEM:
+		return &handler{cmdElem, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_SLICE:
+		return &handler{cmdSlice, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_DEPTH:
+		return &handler{cmdDepth, nil, cmd}, args.Strings(), nil
+
+	case CMD_REL:
+		if pairMode {
+			return &handler{cmdPairRel, nil, cmd}, args.Strings(), nil
+		}
+
+		return &handler{cmdRel, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_REL_REAL:
+		return &handler{cmdRelReal, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_REL_UNDER:
+		return &handler{cmdRelUnder, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_TRUNCATE:
+		return &handler{cmdTruncate, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_MAX_LEN:
+		return &handler{cmdMaxLen, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_MAX_NAME_LEN:
+		return &handler{cmdMaxNameLen, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_MAX_DEPTH:
+		return &handler{cmdMaxDepth, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
 
 	case CMD_ADD_PREFIX:
-		return &handler{cmdAddPrefix, args[:minArgs]}, args[minArgs:].Strings(), nil
+		return &handler{cmdAddPrefix, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
 
 	case CMD_DEL_PREFIX:
-		return &handler{cmdDelPrefix, args[:minArgs]}, args[minArgs:].Strings(), nil
+		return &handler{cmdDelPrefix, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
 
 	case CMD_ADD_SUFFIX:
-		return &handler{cmdAddSuffix, args[:minArgs]}, args[minArgs:].Strings(), nil
+		return &handler{cmdAddSuffix, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
 
 	case CMD_DEL_SUFFIX:
-		return &handler{cmdDelSuffix, args[:minArgs]}, args[minArgs:].Strings(), nil
+		return &handler{cmdDelSuffix, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
 
 	case CMD_EXCLUDE:
-		return &handler{cmdExclude, args[:minArgs]}, args[minArgs:].Strings(), nil
+		return &handler{cmdExclude, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
 
 	case CMD_REPLACE:
-		return &handler{cmdReplace, args[:minArgs]}, args[minArgs:].Strings(), nil
+		return &handler{cmdReplace, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_LOWER:
+		return &handler{cmdLower, nil, cmd}, args.Strings(), nil
+
+	case CMD_UPPER:
+		return &handler{cmdUpper, nil, cmd}, args.Strings(), nil
+
+	case CMD_SNAKE:
+		return &handler{cmdSnake, nil, cmd}, args.Strings(), nil
+
+	case CMD_KEBAB:
+		return &handler{cmdKebab, nil, cmd}, args.Strings(), nil
+
+	case CMD_CAMEL:
+		return &handler{cmdCamel, nil, cmd}, args.Strings(), nil
+
+	case CMD_PASCAL:
+		return &handler{cmdPascal, nil, cmd}, args.Strings(), nil
+
+	case CMD_TITLE:
+		return &handler{cmdTitle, nil, cmd}, args.Strings(), nil
+
+	case CMD_SLUG:
+		return &handler{cmdSlug, nil, cmd}, args.Strings(), nil
+
+	case CMD_NFC:
+		return &handler{cmdNFC, nil, cmd}, args.Strings(), nil
+
+	case CMD_NFD:
+		return &handler{cmdNFD, nil, cmd}, args.Strings(), nil
+
+	case CMD_NFKC:
+		return &handler{cmdNFKC, nil, cmd}, args.Strings(), nil
+
+	case CMD_FOLD:
+		return &handler{cmdFold, nil, cmd}, args.Strings(), nil
+
+	case CMD_SANITIZE:
+		return &handler{cmdSanitize, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_QUOTE:
+		return &handler{cmdQuote, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_UNQUOTE:
+		return &handler{cmdUnquote, nil, cmd}, args.Strings(), nil
+
+	case CMD_ESCAPE:
+		return &handler{cmdEscape, nil, cmd}, args.Strings(), nil
+
+	case CMD_UNESCAPE:
+		return &handler{cmdUnescape, nil, cmd}, args.Strings(), nil
+
+	case CMD_TO_URI:
+		return &handler{cmdToURI, nil, cmd}, args.Strings(), nil
+
+	case CMD_FROM_URI:
+		return &handler{cmdFromURI, nil, cmd}, args.Strings(), nil
+
+	case CMD_URL_ENCODE:
+		return &handler{cmdURLEncode, nil, cmd}, args.Strings(), nil
+
+	case CMD_URL_DECODE:
+		return &handler{cmdURLDecode, nil, cmd}, args.Strings(), nil
 
-	case CMD_LOWER, "lower-case":
-		return &handler{cmdLower, nil}, args.Strings(), nil
+	case CMD_TO_POSIX:
+		return &handler{cmdToPosix, nil, cmd}, args.Strings(), nil
 
-	case CMD_UPPER, "upper-case":
-		return &handler{cmdUpper, nil}, args.Strings(), nil
+	case CMD_TO_WINDOWS:
+		return &handler{cmdToWindows, nil, cmd}, args.Strings(), nil
+
+	case CMD_TO_WSL:
+		return &handler{cmdToWSL, nil, cmd}, args.Strings(), nil
+
+	case CMD_TO_CYGWIN:
+		return &handler{cmdToCygwin, nil, cmd}, args.Strings(), nil
 
 	case CMD_STRIP_EXT:
-		return &handler{cmdStripExt, nil}, args.Strings(), nil
+		return &handler{cmdStripExt, nil, cmd}, args.Strings(), nil
+
+	case CMD_SET_EXT:
+		return &handler{cmdSetExt, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_SET_BASE:
+		return &handler{cmdSetBase, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_SET_DIR:
+		return &handler{cmdSetDir, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_EACH, CMD_EACH_DIR, CMD_EACH_BASE:
+		return newEachHandler(cmd, args)
 
 	case CMD_IS_ABS:
-		return &handler{cmdIsAbs, nil}, args.Strings(), nil
+		return &handler{cmdIsAbs, nil, cmd}, args.Strings(), nil
 
 	case CMD_IS_LOCAL:
-		return &handler{cmdIsLocal, nil}, args.Strings(), nil
+		return &handler{cmdIsLocal, nil, cmd}, args.Strings(), nil
 
 	case CMD_IS_SAFE:
-		return &handler{cmdIsSafe, nil}, args.Strings(), nil
+		return &handler{cmdIsSafe, nil, cmd}, args.Strings(), nil
 
 	case CMD_IS_MATCH:
-		return &handler{cmdIsMatch, args[:minArgs]}, args[minArgs:].Strings(), nil
+		return &handler{cmdIsMatch, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_IS_NORMALIZED:
+		return &handler{cmdIsNormalized, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_IS_PORTABLE:
+		return &handler{cmdIsPortable, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_IS_WITHIN:
+		return &handler{cmdIsWithinLimits, args[:minArgs], cmd}, args[minArgs:].Strings(), nil
+
+	case CMD_SAME_FILE:
+		return newPairHandler(cmdSameFile, cmd, args)
+
+	case CMD_COMMON:
+		return newPairHandler(cmdCommon, cmd, args)
+
+	case CMD_IS_UNDER:
+		return newPairHandler(cmdIsUnder, cmd, args)
 	}
 
-	return nil, nil, fmt.Errorf("Unknown command %q", cmd)
+	pluginPath := findPlugin(cmd)
+
+	if pluginPath != "" {
+		p := &plugin{Name: cmd, Path: pluginPath}
+		return &handler{p.Handle, nil, cmd}, args.Strings(), nil
+	}
+
+	return nil, nil, unknownCommandError(cmd)
 }
 
-// executePipeHandlers executes all handlers in pipe with given data
-func executePipeHandlers(p pipe, data string) (error, bool) {
+// executePipeHandlers executes all handlers in pipe with given data and returns
+// all non-empty results
+func executePipeHandlers(p pipe, data string) ([]string, error, bool) {
+	return executePipeStages(p, 0, data, data)
+}
+
+// executePipeStages executes handlers in pipe starting from given stage
+func executePipeStages(p pipe, start int, data, record string) ([]string, error, bool) {
 	var err error
 	var ok bool
 
-	for _, cmd := range p {
-		data, err, ok = cmd.Func(data, cmd.Args)
+	for i := start; i < len(p); i++ {
+		cmd := p[i]
+
+		switch {
+		case isPair(data) && multiCommands[cmd.Name]:
+			err, ok = fmt.Errorf("Command can't be used with pair of paths"), false
+		case isPair(data) && !pairCommands[cmd.Name]:
+			data, err, ok = executePairHandler(cmd, data)
+		default:
+			data, err, ok = executeHandler(cmd, data)
+		}
+
+		if err != nil {
+			return nil, stageError(err, cmd, i, formatRecord(record)), false
+		}
+
+		if !ok {
+			return nil, nil, false
+		}
+
+		if multiCommands[cmd.Name] {
+			return executeMultiStages(p, i+1, data, record)
+		}
+	}
+
+	if data == "" {
+		return nil, nil, true
+	}
+
+	return []string{data}, nil, true
+}
+
+// executeHandler executes handler with given data
+func executeHandler(h *handler, data string) (string, error, bool) {
+	if _, ok := urlCommands[h.Name]; ok && isURLRecord(data) {
+		return executeURLHandler(h, data)
+	}
+
+	if pathStyle == STYLE_WINDOWS && isWinCommand(h.Name) {
+		return executeWinHandler(h, data)
+	}
+
+	return h.Func(data, h.Args)
+}
+
+// executeMultiStages executes handlers in pipe for every record returned by
+// command with many results
+func executeMultiStages(p pipe, start int, data, record string) ([]string, error, bool) {
+	var result []string
+
+	if data == "" {
+		return nil, nil, true
+	}
+
+	for _, item := range strings.Split(data, RECORDS_SEP) {
+		items, err, ok := executePipeStages(p, start, item, record)
 
 		if err != nil || !ok {
-			return err, ok
+			return nil, err, ok
 		}
+
+		result = append(result, items...)
 	}
 
-	if data != "" {
-		fmt.Printf("%s%s", data, separator)
+	return result, nil, true
+}
+
+// processData executes all handlers in pipe with given data and prints result
+func processData(p pipe, data string) (error, bool) {
+	result, err, ok := executePipeHandlers(p, data)
+
+	if err != nil || !ok {
+		return err, ok
+	}
+
+	safe := isSafeOutput(p)
+
+	for _, item := range result {
+		fmt.Printf("%s%s", formatOutput(item, safe), separator)
 	}
 
 	return nil, true
 }
 
+// isSafeOutput returns true if output of pipe must be escaped. Output of escape
+// and quote commands is already safe, so it is not escaped twice.
+func isSafeOutput(p pipe) bool {
+	if !safeOutput || len(p) == 0 {
+		return safeOutput
+	}
+
+	switch p[len(p)-1].Name {
+	case CMD_ESCAPE, CMD_QUOTE:
+		return false
+	}
+
+	return true
+}
+
 // printError prints error message to console
 func printError(f string, a ...interface{}) {
 	if quietMode {
@@ -462,11 +1101,20 @@ func printError(f string, a ...interface{}) {
 	terminal.Error(f, a...)
 }
 
+// printTrace prints trace message if trace mode is enabled
+func printTrace(f string, a ...interface{}) {
+	if !traceMode || quietMode {
+		return
+	}
+
+	terminal.Warn(f, a...)
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // printCompletion prints completion for given shell
 func printCompletion() int {
-	info := genUsage()
+	info := addPluginsUsage(genUsage())
 
 	switch options.GetS(OPT_COMPLETION) {
 	case "bash":
@@ -493,37 +1141,43 @@ func genUsage() *usage.Info {
 
 	info.AppNameColorTag = colorTagApp
 
-	info.AddCommand(CMD_BASENAME, "Strip directory and suffix from filenames", "?path…")
-	info.AddCommand(CMD_DIRNAME, "Strip last component from file name", "?path…")
-	info.AddCommand(CMD_DIRNAME_NUM, "Return N elements from path", "num", "?path…")
-	info.AddCommand(CMD_READLINK, "Print resolved symbolic links or canonical file names", "?path…")
-	info.AddCommand(CMD_CLEAN, "Print shortest path name equivalent to path by purely lexical processing", "?path…")
-	info.AddCommand(CMD_COMPACT, "Converts path to compact representation", "?path…")
-	info.AddCommand(CMD_ABS, "Print absolute representation of path", "?path…")
-	info.AddCommand(CMD_EXT, "Print file extension", "?path…")
-	info.AddCommand(CMD_MATCH, "Filter given path using pattern", "pattern", "?path…")
-	info.AddCommand(CMD_JOIN, "Join path elements", "root", "?path…")
-
-	info.AddCommand(CMD_ADD_PREFIX, "Add the substring at the beginning", "prefix", "?path…")
-	info.AddCommand(CMD_DEL_PREFIX, "Remove the substring at the beginning", "prefix", "?path…")
-	info.AddCommand(CMD_ADD_SUFFIX, "Add the substring at the end", "suffix", "?path…")
-	info.AddCommand(CMD_DEL_SUFFIX, "Remove the substring at the end", "suffix", "?path…")
-	info.AddCommand(CMD_EXCLUDE, "Exclude part of the path", "substr", "?path…")
-	info.AddCommand(CMD_REPLACE, "Replace part of the path", "old", "new", "?path…")
-	info.AddCommand(CMD_LOWER, "Convert path to lower case", "?path…")
-	info.AddCommand(CMD_UPPER, "Convert path to upper case", "?path…")
-	info.AddCommand(CMD_STRIP_EXT, "Remove file extension", "?path…")
-
-	info.AddCommand(CMD_IS_ABS, "Check if given path is absolute", "?path…")
-	info.AddCommand(CMD_IS_LOCAL, "Check if given path is local", "?path…")
-	info.AddCommand(CMD_IS_SAFE, "Check if given path is safe", "?path…")
-	info.AddCommand(CMD_IS_MATCH, "Check if given path is match to pattern", "pattern", "?path…")
+	for _, c := range commands {
+		if info.Commands == nil || info.Commands[len(info.Commands)-1].Group != c.Group {
+			info.AddGroup(c.Group)
+		}
+
+		var args []any
+
+		for _, arg := range c.Args {
+			args = append(args, arg)
+		}
+
+		info.AddCommand(c.Name, c.Desc, append(args, "?path…")...)
+	}
+
+	info.AddGroup("Help")
+	info.AddCommand(CMD_HELP, "Show detailed info about command", "command")
 
 	info.AddOption(OPT_ZERO, "End each output line with NUL, not newline")
 	info.AddOption(OPT_SPACE, "End each output line with space, not newline")
+	info.AddOption(OPT_PAIR, "Process pairs of paths {s-}(separated by tab or two NUL-terminated fields){!}")
+	info.AddOption(OPT_INPUT, "Read data from file {s-}(- for stdin, can be used multiple times){!}", "file")
 	info.AddOption(OPT_QUIET, "Suppress all error messages")
+	info.AddOption(OPT_STRICT, "Treat all data processing failures as errors")
+	info.AddOption(OPT_TRACE, "Print reasons of predicates failures")
+	info.AddOption(OPT_MODE, "Records processing mode {s-}(path/url/auto){!}", "mode")
+	info.AddOption(OPT_STYLE, "Path style {s-}(posix/windows){!}", "style")
+	info.AddOption(OPT_SAFE, "Escape non-printable symbols and backslashes in output {s-}(enabled for terminal){!}")
+	info.AddOption(OPT_NO_SAFE, "Don't escape non-printable symbols in output to terminal")
+	info.AddOption(OPT_FULL, "Use compound extensions in ext and strip-ext commands {s-}(like .tar.gz){!}")
+	info.AddOption(OPT_ERRORS, "Errors output format {s-}(text/json){!}", "format")
+	info.AddOption(OPT_SERVE, "Serve requests from stdin or Unix socket {s-}(coprocess mode){!}", "?socket")
 	info.AddOption(OPT_NO_COLOR, "Disable colors in output")
 
+	if withInteractive {
+		info.AddOption(OPT_INTERACTIVE, "Build pipeline interactively using data samples")
+	}
+
 	if withSelfUpdate {
 		info.AddOption(OPT_UPDATE, "Update application to the latest version")
 	}
@@ -532,6 +1186,7 @@ func genUsage() *usage.Info {
 	info.AddOption(OPT_VER, "Show version")
 
 	info.AddEnv("PATH_QUIET", "Flag to suppress all error messages {s-}(Boolean){!}")
+	info.AddEnv(ENV_COMPOUND_EXTS, "Additional compound extensions {s-}(separated by comma or space){!}")
 
 	info.AddExample(
 		"base /path/to/file.txt",
@@ -548,6 +1203,11 @@ func genUsage() *usage.Info {
 		"→ /v/l/p/t/s/file.txt",
 	)
 
+	info.AddExample(
+		"'@stem:add-suffix+_old' /path/to/file.txt",
+		"→ /path/to/file_old.txt",
+	)
+
 	info.AddExample(
 		"path abs,strip-ext *",
 		"Run many commands at once using piping",
@@ -558,11 +1218,31 @@ func genUsage() *usage.Info {
 		"Run many commands at once using piping with stdin data",
 	)
 
+	info.AddRawExample(
+		`path 'base,strip-ext' -i list1.txt -i - @list2.txt < list3.txt`,
+		"Process data from arguments, list files and stdin",
+	)
+
+	info.AddExample(
+		"base @list.txt @@scope/package.json",
+		"Process paths from list file and path which starts with @",
+	)
+
+	info.AddRawExample(
+		`path --pair -i sources.txt -i targets.txt rel`,
+		"Print paths from targets.txt relative to paths from sources.txt",
+	)
+
 	info.AddRawExample(
 		"ls -1 | path is-match '*.txt' && echo MATCH!",
 		"Check if all files in current directory is match to pattern",
 	)
 
+	info.AddRawExample(
+		`printf 'base,upper\t/path/to/file.txt\n' | path --serve`,
+		"Run path in coprocess mode and process request from stdin",
+	)
+
 	info.AddRawExample(
 		"PATH_QUIET=1 path dir /path/to/file.txt",
 		"Run dir command in quiet mode enabled by environment variable",
@@ -571,6 +1251,23 @@ func genUsage() *usage.Info {
 	return info
 }
 
+// addPluginsUsage adds info about plugins found in $PATH to usage info
+func addPluginsUsage(info *usage.Info) *usage.Info {
+	plugins := listPlugins()
+
+	if len(plugins) == 0 {
+		return info
+	}
+
+	info.AddGroup("Plugins")
+
+	for _, name := range plugins {
+		info.AddCommand(name, "External command {s-}("+PLUGIN_PREFIX+name+"){!}", "?path…")
+	}
+
+	return info
+}
+
 // genAbout generates info about version
 func genAbout(gitRev string) *usage.About {
 	about := &usage.About{
@@ -9,6 +9,7 @@ package app
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -16,10 +17,115 @@ import (
 	"github.com/essentialkaos/ek/v13/options"
 	"github.com/essentialkaos/ek/v13/path"
 	"github.com/essentialkaos/ek/v13/strutil"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// normForms contains supported Unicode normalization forms
+var normForms = map[string]norm.Form{
+	"nfc":  norm.NFC,
+	"nfd":  norm.NFD,
+	"nfkc": norm.NFKC,
+	"nfkd": norm.NFKD,
+}
+
+// caseFolder is caser used for Unicode case folding
+var caseFolder = cases.Fold()
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// validateDirNum checks that number of directories from command arguments is valid
+func validateDirNum(args options.Arguments) error {
+	_, err := parseDirNum(args.Get(0).String())
+	return err
+}
+
+// validateExtSegments checks that number of extension segments from command
+// arguments is valid
+func validateExtSegments(args options.Arguments) error {
+	_, err := parseExtSegments(args.Get(0).String())
+	return err
+}
+
+// validatePattern checks that pattern from command arguments is valid
+func validatePattern(args options.Arguments) error {
+	pattern := args.Get(0).String()
+
+	if pathStyle == STYLE_WINDOWS {
+		pattern = winPattern(pattern)
+	}
+
+	_, err := filepath.Match(pattern, "")
+
+	if err != nil {
+		return fmt.Errorf("Invalid pattern %q: %v", args.Get(0).String(), err)
+	}
+
+	return nil
+}
+
+// validateIndex checks that index of path element from command arguments is valid
+func validateIndex(args options.Arguments) error {
+	_, err := parseIndex(args.Get(0).String())
+	return err
+}
+
+// validateSlice checks that slice of path elements from command arguments is valid
+func validateSlice(args options.Arguments) error {
+	_, _, err := parseSlice(args.Get(0).String(), 0)
+	return err
+}
+
+// validateNormForm checks that Unicode normalization form from command arguments
+// is supported
+func validateNormForm(args options.Arguments) error {
+	_, err := getNormForm(args.Get(0).String())
+	return err
+}
+
+// validateProfile checks that sanitization profile from command arguments is
+// supported
+func validateProfile(args options.Arguments) error {
+	_, err := getProfile(args.Get(0).String())
+	return err
+}
+
+// validateLimit checks that limit from command arguments is valid
+func validateLimit(args options.Arguments) error {
+	_, err := parseLimit(args.Get(0).String())
+	return err
+}
+
+// validateWidth checks that width from command arguments is valid
+func validateWidth(args options.Arguments) error {
+	_, err := parseWidth(args.Get(0).String())
+	return err
+}
+
+// validateLimitsProfile checks that limits profile from command arguments is
+// supported
+func validateLimitsProfile(args options.Arguments) error {
+	_, err := getLimitsChecker(args.Get(0).String())
+	return err
+}
+
+// validateQuoteStyle checks that quoting style from command arguments is supported
+func validateQuoteStyle(args options.Arguments) error {
+	_, err := getQuoteStyle(args.Get(0).String())
+	return err
+}
+
+// validateCompactOptions checks that options of compact command are valid
+func validateCompactOptions(args options.Arguments) error {
+	_, err := parseCompactOptions(args)
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // cmdBasename is handler for "base" command
 func cmdBasename(data string, args options.Arguments) (string, error, bool) {
 	return path.Base(data), nil, true
@@ -32,10 +138,10 @@ func cmdDirname(data string, args options.Arguments) (string, error, bool) {
 
 // cmdDirnameNum is handler for "dirn" command
 func cmdDirnameNum(data string, args options.Arguments) (string, error, bool) {
-	num, err := strconv.Atoi(strings.ReplaceAll(args.Get(0).String(), "^", "-"))
+	num, err := parseDirNum(args.Get(0).String())
 
 	if err != nil {
-		return "", fmt.Errorf("Can't parse number of directories: %v", err), false
+		return "", err, false
 	}
 
 	return path.DirN(data, num), nil, true
@@ -43,7 +149,12 @@ func cmdDirnameNum(data string, args options.Arguments) (string, error, bool) {
 
 // cmdReadlink is handler for "link" command
 func cmdReadlink(data string, args options.Arguments) (string, error, bool) {
-	dest, _ := filepath.EvalSymlinks(data)
+	dest, err := filepath.EvalSymlinks(data)
+
+	if err != nil && strictMode {
+		return "", fmt.Errorf("Can't resolve symbolic links: %v", err), false
+	}
+
 	return strutil.B(dest != "", dest, data), nil, true
 }
 
@@ -54,20 +165,89 @@ func cmdClean(data string, args options.Arguments) (string, error, bool) {
 
 // cmdCompact is handler for "compact" command
 func cmdCompact(data string, args options.Arguments) (string, error, bool) {
-	return path.Compact(data), nil, true
+	if len(args) == 0 {
+		return path.Compact(data), nil, true
+	}
+
+	opts, err := parseCompactOptions(args)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return compactPath(data, opts), nil, true
+}
+
+// cmdTruncate is handler for "truncate" command
+func cmdTruncate(data string, args options.Arguments) (string, error, bool) {
+	width, err := parseWidth(args.Get(0).String())
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return truncatePath(data, width), nil, true
 }
 
 // cmdExt is handler for "ext" command
 func cmdExt(data string, args options.Arguments) (string, error, bool) {
-	return path.Ext(data), nil, true
+	if args.Has(0) {
+		num, err := parseExtSegments(args.Get(0).String())
+
+		if err != nil {
+			return "", err, false
+		}
+
+		_, base, _ := splitPath(data)
+
+		return getExtN(base, num), nil, true
+	}
+
+	ext := path.Ext(data)
+
+	if fullExtMode && ext != "" {
+		_, base, _ := splitPath(data)
+		ext = strutil.Q(getCompoundExt(base), ext)
+	}
+
+	return ext, nil, true
+}
+
+// cmdStem is handler for "stem" command
+func cmdStem(data string, args options.Arguments) (string, error, bool) {
+	_, base, _ := splitPath(data)
+	stem, _ := splitExt(base)
+
+	return stem, nil, true
 }
 
 // cmdAbs is handler for "abs" command
 func cmdAbs(data string, args options.Arguments) (string, error, bool) {
-	dest, _ := filepath.Abs(data)
+	dest, err := filepath.Abs(data)
+
+	if err != nil && strictMode {
+		return "", fmt.Errorf("Can't get absolute path: %v", err), false
+	}
+
 	return strutil.B(dest != "", dest, data), nil, true
 }
 
+// cmdExpand is handler for "expand" command
+func cmdExpand(data string, args options.Arguments) (string, error, bool) {
+	data, err := expandPath(data)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return data, nil, true
+}
+
+// cmdTilde is handler for "tilde" command
+func cmdTilde(data string, args options.Arguments) (string, error, bool) {
+	return tildePath(data), nil, true
+}
+
 // cmdMatch is handler for "match" command
 func cmdMatch(data string, args options.Arguments) (string, error, bool) {
 	isMatch, _ := filepath.Match(args.Get(0).String(), data)
@@ -85,6 +265,82 @@ func cmdJoin(data string, args options.Arguments) (string, error, bool) {
 	return path, nil, true
 }
 
+// cmdRel is handler for "rel" command
+func cmdRel(data string, args options.Arguments) (string, error, bool) {
+	path, err := relPath(data, args.Get(0).String(), false)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return path, nil, true
+}
+
+// cmdRelReal is handler for "rel-real" command
+func cmdRelReal(data string, args options.Arguments) (string, error, bool) {
+	path, err := relPath(data, args.Get(0).String(), true)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return path, nil, true
+}
+
+// cmdRelUnder is handler for "rel-under" command
+func cmdRelUnder(data string, args options.Arguments) (string, error, bool) {
+	path, err := relPath(data, args.Get(0).String(), false)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	if path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return absPath(data), nil, true
+	}
+
+	return path, nil, true
+}
+
+// cmdMaxLen is handler for "max-len" command
+func cmdMaxLen(data string, args options.Arguments) (string, error, bool) {
+	limit, err := parseLimit(args.Get(0).String())
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return strutil.B(len(data) <= limit, data, ""), nil, true
+}
+
+// cmdMaxNameLen is handler for "max-name-len" command
+func cmdMaxNameLen(data string, args options.Arguments) (string, error, bool) {
+	limit, err := parseLimit(args.Get(0).String())
+
+	if err != nil {
+		return "", err, false
+	}
+
+	for _, name := range strings.Split(data, "/") {
+		if len(name) > limit {
+			return "", nil, true
+		}
+	}
+
+	return data, nil, true
+}
+
+// cmdMaxDepth is handler for "max-depth" command
+func cmdMaxDepth(data string, args options.Arguments) (string, error, bool) {
+	limit, err := parseLimit(args.Get(0).String())
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return strutil.B(len(pathElems(data)) <= limit, data, ""), nil, true
+}
+
 // cmdAddPrefix is handler for "add-prefix" command
 func cmdAddPrefix(data string, args options.Arguments) (string, error, bool) {
 	return args.Get(0).String() + data, nil, true
@@ -129,6 +385,218 @@ func cmdUpper(data string, args options.Arguments) (string, error, bool) {
 	return strings.ToUpper(data), nil, true
 }
 
+// cmdSnake is handler for "snake" command
+func cmdSnake(data string, args options.Arguments) (string, error, bool) {
+	return convertStem(data, func(words []string) string {
+		return joinWords(words, "_")
+	}), nil, true
+}
+
+// cmdKebab is handler for "kebab" command
+func cmdKebab(data string, args options.Arguments) (string, error, bool) {
+	return convertStem(data, func(words []string) string {
+		return joinWords(words, "-")
+	}), nil, true
+}
+
+// cmdCamel is handler for "camel" command
+func cmdCamel(data string, args options.Arguments) (string, error, bool) {
+	return convertStem(data, func(words []string) string {
+		return strings.ToLower(words[0]) + joinTitleWords(words[1:], "")
+	}), nil, true
+}
+
+// cmdPascal is handler for "pascal" command
+func cmdPascal(data string, args options.Arguments) (string, error, bool) {
+	return convertStem(data, func(words []string) string {
+		return joinTitleWords(words, "")
+	}), nil, true
+}
+
+// cmdTitle is handler for "title" command
+func cmdTitle(data string, args options.Arguments) (string, error, bool) {
+	return convertStem(data, func(words []string) string {
+		return joinTitleWords(words, " ")
+	}), nil, true
+}
+
+// cmdSlug is handler for "slug" command
+func cmdSlug(data string, args options.Arguments) (string, error, bool) {
+	return convertStem(data, func(words []string) string {
+		var slugWords []string
+
+		// Words are transliterated after splitting, because transliteration
+		// of upper case letters (Ж → Zh) adds case changes
+		for _, word := range words {
+			if word = transliterate(word); word != "" {
+				slugWords = append(slugWords, word)
+			}
+		}
+
+		slug := joinWords(slugWords, "-")
+
+		// Name can't be empty, so it is kept as is if it can't be transliterated
+		return strutil.Q(slug, strings.Join(words, "-"))
+	}), nil, true
+}
+
+// cmdNFC is handler for "nfc" command
+func cmdNFC(data string, args options.Arguments) (string, error, bool) {
+	return norm.NFC.String(data), nil, true
+}
+
+// cmdNFD is handler for "nfd" command
+func cmdNFD(data string, args options.Arguments) (string, error, bool) {
+	return norm.NFD.String(data), nil, true
+}
+
+// cmdNFKC is handler for "nfkc" command
+func cmdNFKC(data string, args options.Arguments) (string, error, bool) {
+	return norm.NFKC.String(data), nil, true
+}
+
+// cmdFold is handler for "fold" command
+func cmdFold(data string, args options.Arguments) (string, error, bool) {
+	return caseFolder.String(data), nil, true
+}
+
+// cmdSanitize is handler for "sanitize" command
+func cmdSanitize(data string, args options.Arguments) (string, error, bool) {
+	p, err := getProfile(args.Get(0).String())
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return p.Sanitize(data), nil, true
+}
+
+// cmdQuote is handler for "quote" command
+func cmdQuote(data string, args options.Arguments) (string, error, bool) {
+	style, err := getQuoteStyle(args.Get(0).String())
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return quote(data, style), nil, true
+}
+
+// cmdUnquote is handler for "unquote" command
+func cmdUnquote(data string, args options.Arguments) (string, error, bool) {
+	style := QUOTE_SH
+
+	if args.Has(0) {
+		var err error
+
+		style, err = getQuoteStyle(args.Get(0).String())
+
+		if err != nil {
+			return "", err, false
+		}
+	}
+
+	data, err := unquote(data, style)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	err = checkNUL(data)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return data, nil, true
+}
+
+// cmdEscape is handler for "escape" command
+func cmdEscape(data string, args options.Arguments) (string, error, bool) {
+	return escapeString(data), nil, true
+}
+
+// cmdUnescape is handler for "unescape" command
+func cmdUnescape(data string, args options.Arguments) (string, error, bool) {
+	data, err := unescapeString(data)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	err = checkNUL(data)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return data, nil, true
+}
+
+// cmdToURI is handler for "to-uri" command
+func cmdToURI(data string, args options.Arguments) (string, error, bool) {
+	return pathToURI(data), nil, true
+}
+
+// cmdFromURI is handler for "from-uri" command
+func cmdFromURI(data string, args options.Arguments) (string, error, bool) {
+	data, err := uriToPath(data)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	err = checkNUL(data)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return data, nil, true
+}
+
+// cmdURLEncode is handler for "url-encode" command
+func cmdURLEncode(data string, args options.Arguments) (string, error, bool) {
+	return urlEncode(data), nil, true
+}
+
+// cmdURLDecode is handler for "url-decode" command
+func cmdURLDecode(data string, args options.Arguments) (string, error, bool) {
+	data, err := urlDecode(data)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't decode path: %v", err), false
+	}
+
+	err = checkNUL(data)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return data, nil, true
+}
+
+// cmdToPosix is handler for "to-posix" command
+func cmdToPosix(data string, args options.Arguments) (string, error, bool) {
+	return toPosixPath(data), nil, true
+}
+
+// cmdToWindows is handler for "to-windows" command
+func cmdToWindows(data string, args options.Arguments) (string, error, bool) {
+	return toWinPath(data), nil, true
+}
+
+// cmdToWSL is handler for "to-wsl" command
+func cmdToWSL(data string, args options.Arguments) (string, error, bool) {
+	return toDrivePath(data, "/mnt"), nil, true
+}
+
+// cmdToCygwin is handler for "to-cygwin" command
+func cmdToCygwin(data string, args options.Arguments) (string, error, bool) {
+	return toDrivePath(data, "/cygdrive"), nil, true
+}
+
 // cmdStripExt is handler for "strip-ext" command
 func cmdStripExt(data string, args options.Arguments) (string, error, bool) {
 	ext := path.Ext(data)
@@ -137,9 +605,56 @@ func cmdStripExt(data string, args options.Arguments) (string, error, bool) {
 		return data, nil, true
 	}
 
+	if fullExtMode {
+		_, base, _ := splitPath(data)
+		ext = strutil.Q(getCompoundExt(base), ext)
+	}
+
 	return strings.TrimSuffix(data, ext), nil, true
 }
 
+// cmdSetExt is handler for "set-ext" command
+func cmdSetExt(data string, args options.Arguments) (string, error, bool) {
+	dir, base, trailing := splitPath(data)
+
+	if base == "" {
+		return data, nil, true
+	}
+
+	ext := args.Get(0).String()
+
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+
+	stem, _ := splitExt(base)
+
+	return dir + stem + ext + trailing, nil, true
+}
+
+// cmdSetBase is handler for "set-base" command
+func cmdSetBase(data string, args options.Arguments) (string, error, bool) {
+	dir, base, trailing := splitPath(data)
+
+	if base == "" {
+		return data, nil, true
+	}
+
+	return dir + args.Get(0).String() + trailing, nil, true
+}
+
+// cmdSetDir is handler for "set-dir" command
+func cmdSetDir(data string, args options.Arguments) (string, error, bool) {
+	_, base, trailing := splitPath(data)
+	dir := args.Get(0).String()
+
+	if dir != "" && !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+
+	return dir + base + trailing, nil, true
+}
+
 // cmdIsAbs is handler for "is-abs" command
 func cmdIsAbs(data string, args options.Arguments) (string, error, bool) {
 	return "", nil, filepath.IsAbs(data)
@@ -160,3 +675,395 @@ func cmdIsMatch(data string, args options.Arguments) (string, error, bool) {
 	isMatch, _ := filepath.Match(args.Get(0).String(), data)
 	return "", nil, isMatch
 }
+
+// cmdSplit is handler for "split" command
+func cmdSplit(data string, args options.Arguments) (string, error, bool) {
+	return strings.Join(pathElems(data), RECORDS_SEP), nil, true
+}
+
+// cmdElem is handler for "elem" command
+func cmdElem(data string, args options.Arguments) (string, error, bool) {
+	index, err := parseIndex(args.Get(0).String())
+
+	if err != nil {
+		return "", err, false
+	}
+
+	elems := pathElems(data)
+
+	if index < 0 {
+		index += len(elems)
+	}
+
+	if index < 0 || index >= len(elems) {
+		if strictMode {
+			return "", fmt.Errorf("Index %s is out of range (path has %d elements)", args.Get(0).String(), len(elems)), false
+		}
+
+		return "", nil, true
+	}
+
+	return elems[index], nil, true
+}
+
+// cmdSlice is handler for "slice" command
+func cmdSlice(data string, args options.Arguments) (string, error, bool) {
+	elems := pathElems(data)
+	start, end, err := parseSlice(args.Get(0).String(), len(elems))
+
+	if err != nil {
+		return "", err, false
+	}
+
+	if start >= end {
+		return "", nil, true
+	}
+
+	result := strings.Join(elems[start:end], "/")
+
+	if start == 0 && strings.HasPrefix(data, "/") {
+		result = "/" + result
+	}
+
+	return result, nil, true
+}
+
+// cmdDepth is handler for "depth" command
+func cmdDepth(data string, args options.Arguments) (string, error, bool) {
+	return strconv.Itoa(len(pathElems(data))), nil, true
+}
+
+// cmdIsNormalized is handler for "is-normalized" command
+func cmdIsNormalized(data string, args options.Arguments) (string, error, bool) {
+	form, err := getNormForm(args.Get(0).String())
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return "", nil, form.IsNormalString(data)
+}
+
+// cmdIsPortable is handler for "is-portable" command
+func cmdIsPortable(data string, args options.Arguments) (string, error, bool) {
+	p, err := getProfile(args.Get(0).String())
+
+	if err != nil {
+		return "", err, false
+	}
+
+	problem := p.Check(data)
+
+	if problem != "" {
+		printTrace("%s: %s (profile: %s)", data, problem, strings.ToLower(args.Get(0).String()))
+		return "", nil, false
+	}
+
+	return "", nil, true
+}
+
+// cmdIsWithinLimits is handler for "is-within-limits" command
+func cmdIsWithinLimits(data string, args options.Arguments) (string, error, bool) {
+	checker, err := getLimitsChecker(args.Get(0).String())
+
+	if err != nil {
+		return "", err, false
+	}
+
+	problem := checker(data)
+
+	if problem != "" {
+		printTrace("%s: %s (profile: %s)", data, problem, strings.ToLower(args.Get(0).String()))
+		return "", nil, false
+	}
+
+	return "", nil, true
+}
+
+// cmdPairRel is handler for "rel" command in pair mode
+func cmdPairRel(data string, args options.Arguments) (string, error, bool) {
+	a, b, err := splitPair(data)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	path, err := relPath(b, a, false)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return path, nil, true
+}
+
+// cmdSameFile is handler for "same-file" command
+func cmdSameFile(data string, args options.Arguments) (string, error, bool) {
+	a, b, err := splitPair(data)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	aInfo, aErr := os.Stat(a)
+	bInfo, bErr := os.Stat(b)
+
+	if aErr != nil || bErr != nil {
+		if strictMode {
+			err := aErr
+
+			if err == nil {
+				err = bErr
+			}
+
+			return "", fmt.Errorf("Can't get info about file: %v", err), false
+		}
+
+		return "", nil, false
+	}
+
+	return "", nil, os.SameFile(aInfo, bInfo)
+}
+
+// cmdCommon is handler for "common" command
+func cmdCommon(data string, args options.Arguments) (string, error, bool) {
+	a, b, err := splitPair(data)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return commonPath(a, b), nil, true
+}
+
+// cmdIsUnder is handler for "is-under" command
+func cmdIsUnder(data string, args options.Arguments) (string, error, bool) {
+	a, b, err := splitPair(data)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	path, err := relPath(a, b, false)
+
+	if err != nil {
+		return "", err, false
+	}
+
+	return "", nil, path != ".." && !strings.HasPrefix(path, ".."+string(filepath.Separator))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// nestedHandlerFunc creates handler function which runs nested command using
+// given executor. Arguments passed to the handler in pipeline are arguments of
+// nested command (e.g. plugin arguments).
+func nestedHandlerFunc(nested *handler, exec func(h *handler, data string) (string, error, bool)) handlerFunc {
+	return func(data string, args options.Arguments) (string, error, bool) {
+		h := nested
+
+		if h.Args == nil && len(args) != 0 {
+			h = &handler{nested.Func, args, nested.Name}
+		}
+
+		return exec(h, data)
+	}
+}
+
+// absPath returns absolute representation of path or path as is if it can't be
+// converted
+func absPath(path string) string {
+	dest, err := filepath.Abs(path)
+	return strutil.B(err == nil, dest, path)
+}
+
+// relPath returns path relative to base
+func relPath(path, base string, resolve bool) (string, error) {
+	path, base = absPath(path), absPath(base)
+
+	if resolve {
+		for _, p := range []*string{&path, &base} {
+			dest, err := filepath.EvalSymlinks(*p)
+
+			if err != nil && strictMode {
+				return "", fmt.Errorf("Can't resolve symbolic links: %v", err)
+			}
+
+			*p = strutil.Q(dest, *p)
+		}
+	}
+
+	rel, err := filepath.Rel(base, path)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't make path relative to %q: %v", base, err)
+	}
+
+	return rel, nil
+}
+
+// commonPath returns the longest common ancestor of two paths
+func commonPath(a, b string) string {
+	if filepath.IsAbs(a) != filepath.IsAbs(b) {
+		a, b = absPath(a), absPath(b)
+	}
+
+	a, b = filepath.Clean(a), filepath.Clean(b)
+
+	aElems := strings.Split(a, string(filepath.Separator))
+	bElems := strings.Split(b, string(filepath.Separator))
+
+	var i int
+
+	for i < len(aElems) && i < len(bElems) && aElems[i] == bElems[i] {
+		i++
+	}
+
+	result := strings.Join(aElems[:i], string(filepath.Separator))
+
+	switch {
+	case result == "" && filepath.IsAbs(a):
+		return string(filepath.Separator)
+	case result == "":
+		return "."
+	}
+
+	return result
+}
+
+// pathElems returns all non-empty elements of path except root
+func pathElems(data string) []string {
+	var result []string
+
+	for _, elem := range strings.Split(data, "/") {
+		if elem != "" {
+			result = append(result, elem)
+		}
+	}
+
+	return result
+}
+
+// parseIndex parses index of path element (negative index or index with "^"
+// prefix is counted from the end of path)
+func parseIndex(data string) (int, error) {
+	index, err := strconv.Atoi(strings.Replace(data, "^", "-", 1))
+
+	if err != nil {
+		return 0, fmt.Errorf("Can't parse index %q", data)
+	}
+
+	return index, nil
+}
+
+// parseSlice parses slice of path elements in "start:end" format and returns
+// bounds for path with given number of elements
+func parseSlice(data string, size int) (int, int, error) {
+	startStr, endStr, ok := strings.Cut(data, ":")
+
+	if !ok {
+		return 0, 0, fmt.Errorf("Can't parse slice %q: slice must be in \"start:end\" format", data)
+	}
+
+	start, end := 0, size
+
+	for _, b := range []struct {
+		str string
+		val *int
+	}{{startStr, &start}, {endStr, &end}} {
+		if b.str == "" {
+			continue
+		}
+
+		index, err := parseIndex(b.str)
+
+		if err != nil {
+			return 0, 0, fmt.Errorf("Can't parse slice %q: invalid bound %q", data, b.str)
+		}
+
+		if index < 0 {
+			index += size
+		}
+
+		*b.val = max(0, min(index, size))
+	}
+
+	return start, end, nil
+}
+
+// splitPath splits path into directory (with trailing slash), the last element
+// and trailing slashes
+func splitPath(data string) (string, string, string) {
+	trimmed := strings.TrimRight(data, "/")
+	i := strings.LastIndexByte(trimmed, '/')
+
+	return trimmed[:i+1], trimmed[i+1:], data[len(trimmed):]
+}
+
+// splitExt splits path element into stem and extension. Dotfiles (like .bashrc)
+// don't have extension.
+func splitExt(base string) (string, string) {
+	ext := path.Ext(base)
+	stem := base[:len(base)-len(ext)]
+
+	if strings.TrimLeft(stem, ".") == "" {
+		return base, ""
+	}
+
+	return stem, ext
+}
+
+// getNormForm returns Unicode normalization form with given name
+func getNormForm(name string) (norm.Form, error) {
+	form, ok := normForms[strings.ToLower(name)]
+
+	if !ok {
+		return 0, fmt.Errorf("Unsupported normalization form %q (supported forms: nfc, nfd, nfkc, nfkd)", name)
+	}
+
+	return form, nil
+}
+
+// parseLimit parses limit from command arguments
+func parseLimit(data string) (int, error) {
+	limit, err := strconv.Atoi(data)
+
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("Invalid limit %q", data)
+	}
+
+	return limit, nil
+}
+
+// parseDirNum parses number of directories for dirn command
+func parseDirNum(data string) (int, error) {
+	num, err := strconv.Atoi(strings.ReplaceAll(data, "^", "-"))
+
+	if err != nil {
+		return 0, fmt.Errorf("Can't parse number of directories: %v", err)
+	}
+
+	return num, nil
+}
+
+// parseExtSegments parses number of extension segments for ext command
+func parseExtSegments(data string) (int, error) {
+	num, err := strconv.Atoi(data)
+
+	if err != nil || num < 1 {
+		return 0, fmt.Errorf("Invalid number of extension segments %q", data)
+	}
+
+	return num, nil
+}
+
+// parseWidth parses width of truncated path
+func parseWidth(data string) (int, error) {
+	width, err := strconv.Atoi(data)
+
+	if err != nil || width < 1 {
+		return 0, fmt.Errorf("Invalid width %q", data)
+	}
+
+	return width, nil
+}
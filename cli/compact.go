@@ -0,0 +1,212 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/essentialkaos/ek/v13/options"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Compact command options
+const (
+	COMPACT_TILDE  = "tilde"
+	COMPACT_UNIQUE = "unique"
+	COMPACT_KEEP   = "keep"
+	COMPACT_LEN    = "len"
+)
+
+// ELLIPSIS is symbol used for marking truncated part of path
+const ELLIPSIS = "…"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// compactOptions contains options of compact command
+type compactOptions struct {
+	Tilde  bool // Replace home directory with tilde
+	Unique bool // Use minimum unique prefixes of directories names
+	Keep   int  // Number of the last path elements kept intact
+	Len    int  // Length of abbreviated names
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseCompactOptions parses options of compact command
+func parseCompactOptions(args options.Arguments) (*compactOptions, error) {
+	opts := &compactOptions{Keep: 1, Len: 1}
+
+	for _, arg := range args {
+		name, value, _ := strings.Cut(arg.String(), "=")
+
+		var err error
+
+		switch name {
+		case COMPACT_TILDE:
+			opts.Tilde = true
+		case COMPACT_UNIQUE:
+			opts.Unique = true
+		case COMPACT_KEEP:
+			opts.Keep, err = parseCompactNum(value, 0)
+		case COMPACT_LEN:
+			opts.Len, err = parseCompactNum(value, 1)
+		default:
+			return nil, fmt.Errorf("Unknown compact option %q", arg.String())
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value of compact option %q", arg.String())
+		}
+	}
+
+	return opts, nil
+}
+
+// parseCompactNum parses numeric value of compact option
+func parseCompactNum(data string, minValue int) (int, error) {
+	num, err := strconv.Atoi(data)
+
+	if err != nil || num < minValue {
+		return 0, fmt.Errorf("Invalid number %q", data)
+	}
+
+	return num, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// compactPath abbreviates directories in path using given options
+func compactPath(data string, opts *compactOptions) string {
+	if opts.Tilde {
+		data = tildePath(data)
+	}
+
+	elems := strings.Split(data, "/")
+	end := len(elems)
+
+	for end > 0 && elems[end-1] == "" {
+		end--
+	}
+
+	// Real path of parent directory, used for searching unique prefixes
+	parent := "."
+
+	for i := 0; i < end; i++ {
+		elem := elems[i]
+
+		switch {
+		case i == 0 && elem == "":
+			parent = "/"
+			continue
+		case i == 0 && strings.HasPrefix(elem, "~"):
+			parent, _ = expandTilde(elem)
+			continue
+		}
+
+		if i < end-opts.Keep && elem != "" && elem != "." && elem != ".." && !strings.HasSuffix(elem, ":") {
+			if opts.Unique {
+				elems[i] = uniquePrefix(parent, elem, opts.Len)
+			} else {
+				elems[i] = abbrevName(elem, opts.Len)
+			}
+		}
+
+		parent = filepath.Join(parent, elem)
+	}
+
+	return strings.Join(elems, "/")
+}
+
+// truncatePath truncates path to given width in symbols replacing middle part
+// with ellipsis
+func truncatePath(data string, width int) string {
+	if utf8.RuneCountInString(data) <= width {
+		return data
+	}
+
+	if width <= 1 {
+		return ELLIPSIS
+	}
+
+	runes := []rune(data)
+	head := (width - 1) / 2
+	tail := width - 1 - head
+
+	return string(runes[:head]) + ELLIPSIS + string(runes[len(runes)-tail:])
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// abbrevName returns the first symbols of name (leading dot is kept as is)
+func abbrevName(name string, size int) string {
+	if strings.HasPrefix(name, ".") {
+		return "." + truncateRunes(name[1:], size)
+	}
+
+	return truncateRunes(name, size)
+}
+
+// uniquePrefix returns the shortest prefix of directory name which is not
+// a prefix of any other directory in the same parent directory
+func uniquePrefix(parent, name string, minSize int) string {
+	entries, err := os.ReadDir(parent)
+
+	if err != nil {
+		return abbrevName(name, minSize)
+	}
+
+	var siblings []string
+
+	for _, entry := range entries {
+		if entry.Name() != name && entry.IsDir() {
+			siblings = append(siblings, entry.Name())
+		}
+	}
+
+	for size := minSize; size < utf8.RuneCountInString(name); size++ {
+		prefix := abbrevName(name, size)
+
+		if !hasPrefixIn(siblings, prefix) {
+			return prefix
+		}
+	}
+
+	return name
+}
+
+// hasPrefixIn returns true if any of names starts with given prefix
+func hasPrefixIn(names []string, prefix string) bool {
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// truncateRunes returns the first symbols of string
+func truncateRunes(data string, size int) string {
+	for i := range data {
+		if size == 0 {
+			return data[:i]
+		}
+
+		size--
+	}
+
+	return data
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
@@ -0,0 +1,96 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/options"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Path elements targeted by each commands
+const (
+	EACH_ALL  uint8 = iota // All elements
+	EACH_DIRS              // All elements except the last one
+	EACH_BASE              // Only the last element
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// eachTargets contains targeted path elements for each commands
+var eachTargets = map[string]uint8{
+	CMD_EACH:      EACH_ALL,
+	CMD_EACH_DIR:  EACH_DIRS,
+	CMD_EACH_BASE: EACH_BASE,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newEachHandler creates handler which executes nested command for every path
+// element
+func newEachHandler(cmd string, args options.Arguments) (*handler, []string, error) {
+	name := getCommandName(args.Get(0).String())
+
+	switch {
+	case multiCommands[name], pairCommands[name]:
+		return nil, nil, usageError("Command %q can't be used with %s", name, cmd)
+	case getCommandInfo(name) != nil && getCommandInfo(name).Group == GROUP_PREDICATES:
+		return nil, nil, usageError("Predicate %q can't be used with %s", name, cmd)
+	}
+
+	nested, extra, err := createCommandHandler(name, args[1:])
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target := eachTargets[cmd]
+
+	fn := nestedHandlerFunc(nested, func(h *handler, data string) (string, error, bool) {
+		return executeEachHandler(h, target, data)
+	})
+
+	return &handler{fn, nil, cmd}, extra, nil
+}
+
+// executeEachHandler executes handler for targeted path elements and joins
+// them back
+func executeEachHandler(h *handler, target uint8, data string) (string, error, bool) {
+	parts := strings.Split(data, "/")
+	last := len(parts) - 1
+
+	for last > 0 && parts[last] == "" {
+		last--
+	}
+
+	var result []string
+
+	for i, part := range parts {
+		if part == "" || (target == EACH_DIRS && i == last) || (target == EACH_BASE && i != last) {
+			result = append(result, part)
+			continue
+		}
+
+		part, err, ok := h.Func(part, h.Args)
+
+		if err != nil || !ok {
+			return "", err, ok
+		}
+
+		// Element is removed if nested command returned empty result
+		if part != "" {
+			result = append(result, part)
+		}
+	}
+
+	return strings.Join(result, "/"), nil, true
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
@@ -0,0 +1,182 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Exit codes
+const (
+	EC_OK    = 0 // Everything is ok
+	EC_FALSE = 1 // Predicate returned false
+	EC_USAGE = 2 // Usage error (unknown command, wrong arguments, etc.)
+	EC_IO    = 3 // I/O error (can't read input data)
+	EC_DATA  = 4 // Data processing error
+)
+
+// Errors output formats
+const (
+	ERRORS_TEXT = "text"
+	ERRORS_JSON = "json"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// appError contains info about error and its context
+type appError struct {
+	Code   int    // Exit code
+	Err    error  // Original error
+	Stage  string // Pipeline stage (command) name
+	Index  int    // Pipeline stage index (starting from 1)
+	Record string // Processed record
+	Source string // Record source (argument, stdin or file name)
+	Num    int    // Record number in source (starting from 1)
+}
+
+// jsonError is error representation used for JSON output
+type jsonError struct {
+	Code    int     `json:"code"`
+	Type    string  `json:"type"`
+	Message string  `json:"message"`
+	Stage   string  `json:"stage,omitempty"`
+	Index   int     `json:"stage_index,omitempty"`
+	Record  *string `json:"record,omitempty"`
+	Source  string  `json:"source,omitempty"`
+	Num     int     `json:"record_num,omitempty"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// errTypes contains names of error types
+var errTypes = map[int]string{
+	EC_USAGE: "usage",
+	EC_IO:    "io",
+	EC_DATA:  "data",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// usageError creates new usage error
+func usageError(f string, a ...any) error {
+	return &appError{Code: EC_USAGE, Err: fmt.Errorf(f, a...)}
+}
+
+// ioError creates new I/O error
+func ioError(f string, a ...any) error {
+	return &appError{Code: EC_IO, Err: fmt.Errorf(f, a...)}
+}
+
+// stageError creates new data error for pipeline stage
+func stageError(err error, h *handler, index int, record string) error {
+	return &appError{
+		Code:   EC_DATA,
+		Err:    err,
+		Stage:  h.Name,
+		Index:  index + 1,
+		Record: record,
+	}
+}
+
+// sourceError adds info about record source to error
+func sourceError(err error, source string, num int) error {
+	var appErr *appError
+
+	if !errors.As(err, &appErr) {
+		appErr = &appError{Code: EC_DATA, Err: err}
+	}
+
+	if appErr.Source == "" {
+		appErr.Source, appErr.Num = source, num
+	}
+
+	return appErr
+}
+
+// getExitCode returns exit code for given error
+func getExitCode(err error) int {
+	var appErr *appError
+
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+
+	return EC_DATA
+}
+
+// printAppError prints error using format defined by options
+func printAppError(err error, format string) {
+	if quietMode {
+		return
+	}
+
+	if format != ERRORS_JSON {
+		printError(err.Error())
+		return
+	}
+
+	var appErr *appError
+
+	if !errors.As(err, &appErr) {
+		appErr = &appError{Code: EC_DATA, Err: err}
+	}
+
+	data, _ := json.Marshal(appErr.toJSON())
+	fmt.Fprintln(os.Stderr, string(data))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Error returns error message with context info
+func (e *appError) Error() string {
+	var prefix, suffix string
+
+	if e.Source != "" {
+		prefix = fmt.Sprintf("%s:%d: ", e.Source, e.Num)
+	}
+
+	if e.Index > 0 {
+		prefix += fmt.Sprintf("%s (stage %d): ", e.Stage, e.Index)
+		suffix = fmt.Sprintf(" (record: %q)", e.Record)
+	}
+
+	return prefix + e.Err.Error() + suffix
+}
+
+// Unwrap returns original error
+func (e *appError) Unwrap() error {
+	return e.Err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// toJSON converts error to struct for JSON output
+func (e *appError) toJSON() *jsonError {
+	result := &jsonError{
+		Code:    e.Code,
+		Type:    errTypes[e.Code],
+		Message: e.Err.Error(),
+		Source:  e.Source,
+		Num:     e.Num,
+	}
+
+	if e.Index > 0 {
+		result.Stage = e.Stage
+		result.Index = e.Index
+		result.Record = &e.Record
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
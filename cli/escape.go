@@ -0,0 +1,115 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// displayEscapes contains escape sequences for control symbols
+var displayEscapes = map[rune]string{
+	'\a': `\a`, '\b': `\b`, '\t': `\t`, '\n': `\n`,
+	'\v': `\v`, '\f': `\f`, '\r': `\r`, '\\': `\\`,
+}
+
+// displayUnescapes contains symbols for escape sequences
+var displayUnescapes = map[byte]byte{
+	'a': '\a', 'b': '\b', 't': '\t', 'n': '\n',
+	'v': '\v', 'f': '\f', 'r': '\r', '\\': '\\',
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// escapeString escapes all non-printable symbols and invalid UTF-8 sequences
+// in string (like ls -b)
+func escapeString(data string) string {
+	var buf strings.Builder
+
+	for i := 0; i < len(data); {
+		r, size := utf8.DecodeRuneInString(data[i:])
+
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			fmt.Fprintf(&buf, `\x%02x`, data[i])
+		case displayEscapes[r] != "":
+			buf.WriteString(displayEscapes[r])
+		case r < utf8.RuneSelf && !unicode.IsPrint(r):
+			fmt.Fprintf(&buf, `\x%02x`, r)
+		case !unicode.IsPrint(r) && r > 0xFFFF:
+			fmt.Fprintf(&buf, `\U%08x`, r)
+		case !unicode.IsPrint(r):
+			fmt.Fprintf(&buf, `\u%04x`, r)
+		default:
+			buf.WriteString(data[i : i+size])
+		}
+
+		i += size
+	}
+
+	return buf.String()
+}
+
+// unescapeString converts escape sequences created by escapeString back to
+// symbols
+func unescapeString(data string) (string, error) {
+	var buf strings.Builder
+
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' {
+			buf.WriteByte(data[i])
+			continue
+		}
+
+		if i+1 >= len(data) {
+			return "", fmt.Errorf("Unterminated escape sequence at the end of string")
+		}
+
+		c := data[i+1]
+
+		switch c {
+		case 'x', 'u', 'U':
+			size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[c]
+
+			if i+2+size > len(data) {
+				return "", fmt.Errorf("Invalid escape sequence %q", data[i:])
+			}
+
+			code, err := strconv.ParseUint(data[i+2:i+2+size], 16, 32)
+
+			if err != nil {
+				return "", fmt.Errorf("Invalid escape sequence %q", data[i:i+2+size])
+			}
+
+			if c == 'x' {
+				buf.WriteByte(byte(code))
+			} else {
+				buf.WriteRune(rune(code))
+			}
+
+			i += 1 + size
+
+		default:
+			if displayUnescapes[c] == 0 {
+				return "", fmt.Errorf("Unknown escape sequence %q", data[i:i+2])
+			}
+
+			buf.WriteByte(displayUnescapes[c])
+			i++
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
@@ -0,0 +1,198 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// expandPath expands tilde and environment variables in path. In strict mode
+// unset variables and unknown users are treated as errors.
+func expandPath(data string) (string, error) {
+	data, err := expandTilde(data)
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+
+	for i := 0; i < len(data); i++ {
+		if data[i] != '$' || i+1 == len(data) {
+			buf.WriteByte(data[i])
+			continue
+		}
+
+		if data[i+1] == '{' {
+			end := findClosingBrace(data, i+2)
+
+			if end == -1 {
+				return "", fmt.Errorf("Unterminated variable reference in %q", data[i:])
+			}
+
+			value, err := expandVarRef(data[i+2 : end])
+
+			if err != nil {
+				return "", err
+			}
+
+			buf.WriteString(value)
+			i = end
+			continue
+		}
+
+		size := varNameLen(data[i+1:])
+
+		if size == 0 {
+			buf.WriteByte(data[i])
+			continue
+		}
+
+		value, err := getVar(data[i+1 : i+1+size])
+
+		if err != nil {
+			return "", err
+		}
+
+		buf.WriteString(value)
+		i += size
+	}
+
+	return buf.String(), nil
+}
+
+// tildePath replaces home directory prefix in path with tilde
+func tildePath(data string) string {
+	home := strings.TrimRight(os.Getenv("HOME"), "/")
+
+	switch {
+	case home == "":
+		return data
+	case data == home:
+		return "~"
+	case strings.HasPrefix(data, home+"/"):
+		return "~" + data[len(home):]
+	}
+
+	return data
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// expandTilde expands tilde (~ or ~user) at the start of path
+func expandTilde(data string) (string, error) {
+	if !strings.HasPrefix(data, "~") {
+		return data, nil
+	}
+
+	name, rest := data[1:], ""
+
+	if i := strings.IndexByte(name, '/'); i != -1 {
+		name, rest = name[:i], name[i:]
+	}
+
+	if name == "" {
+		home := os.Getenv("HOME")
+
+		if home == "" {
+			if strictMode {
+				return "", fmt.Errorf("Can't expand tilde: HOME is not set")
+			}
+
+			return data, nil
+		}
+
+		return strings.TrimRight(home, "/") + rest, nil
+	}
+
+	usr, err := user.Lookup(name)
+
+	if err != nil {
+		if strictMode {
+			return "", fmt.Errorf("Can't expand tilde: unknown user %q", name)
+		}
+
+		return data, nil
+	}
+
+	return strings.TrimRight(usr.HomeDir, "/") + rest, nil
+}
+
+// expandVarRef expands variable reference in braces (VAR or VAR:-default)
+func expandVarRef(ref string) (string, error) {
+	name, def, hasDefault := strings.Cut(ref, ":-")
+
+	if name == "" || varNameLen(name) != len(name) {
+		return "", fmt.Errorf("Invalid variable reference ${%s}", ref)
+	}
+
+	if !hasDefault {
+		return getVar(name)
+	}
+
+	value := os.Getenv(name)
+
+	if value != "" {
+		return value, nil
+	}
+
+	return expandPath(def)
+}
+
+// getVar returns value of environment variable
+func getVar(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+
+	if !ok && strictMode {
+		return "", fmt.Errorf("Variable %q is not set", name)
+	}
+
+	return value, nil
+}
+
+// varNameLen returns length of variable name at the start of string
+func varNameLen(data string) int {
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		if c != '_' && !isASCIILetter(c) && (i == 0 || c < '0' || c > '9') {
+			return i
+		}
+	}
+
+	return len(data)
+}
+
+// findClosingBrace returns index of closing brace for variable reference
+// taking nested references into account
+func findClosingBrace(data string, start int) int {
+	depth := 1
+
+	for i := start; i < len(data); i++ {
+		switch {
+		case strings.HasPrefix(data[i:], "${"):
+			depth++
+			i++
+		case data[i] == '}':
+			depth--
+
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
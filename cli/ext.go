@@ -0,0 +1,102 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"strings"
+	"unicode"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ENV_COMPOUND_EXTS is name of environment variable with additional compound
+// extensions
+const ENV_COMPOUND_EXTS = "PATH_COMPOUND_EXTS"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// compoundExts contains known compound extensions
+var compoundExts = []string{
+	".tar.gz", ".tar.bz2", ".tar.xz", ".tar.zst", ".tar.lz", ".tar.lz4",
+	".tar.lzma", ".tar.br", ".tar.z", ".pkg.tar.zst", ".pkg.tar.xz",
+	".d.ts", ".d.mts", ".d.cts", ".min.js", ".min.css", ".js.map",
+	".css.map", ".user.js", ".tar.gz.sig", ".tar.gz.asc",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// loadCompoundExts adds compound extensions from environment variable to the
+// list of known extensions
+func loadCompoundExts() {
+	exts := strings.FieldsFunc(os.Getenv(ENV_COMPOUND_EXTS), func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	for _, ext := range exts {
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+
+		compoundExts = append(compoundExts, strings.ToLower(ext))
+	}
+}
+
+// getCompoundExt returns the longest known compound extension of path element
+func getCompoundExt(base string) string {
+	var result string
+
+	name := strings.ToLower(base)
+
+	for _, ext := range compoundExts {
+		if len(ext) > len(result) && strings.HasSuffix(name, ext) &&
+			strings.TrimLeft(base[:len(base)-len(ext)], ".") != "" {
+			result = base[len(base)-len(ext):]
+		}
+	}
+
+	return result
+}
+
+// getExtN returns up to num last dotted segments of path element. Segments which
+// contain only digits (like version numbers) are not treated as extensions.
+func getExtN(base string, num int) string {
+	segments := strings.Split(base, ".")
+	start := len(segments)
+
+	for i := len(segments) - 1; i > 0 && len(segments)-i <= num; i-- {
+		if !isExtSegment(segments[i]) || strings.TrimLeft(strings.Join(segments[:i], "."), ".") == "" {
+			break
+		}
+
+		start = i
+	}
+
+	if start == len(segments) {
+		return ""
+	}
+
+	return "." + strings.Join(segments[start:], ".")
+}
+
+// isExtSegment returns true if dotted segment can be a part of extension
+func isExtSegment(segment string) bool {
+	if segment == "" {
+		return false
+	}
+
+	for _, r := range segment {
+		if !unicode.IsDigit(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
@@ -0,0 +1,822 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/spellcheck"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Commands groups
+const (
+	GROUP_BASIC      = "Commands"
+	GROUP_MODIFY     = "Modification commands"
+	GROUP_PREDICATES = "Predicates"
+	GROUP_PAIR       = "Pair commands"
+	GROUP_SCOPES     = "Scope modifiers"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdInfo contains info about command
+type cmdInfo struct {
+	Name     string        // Command name
+	Group    string        // Commands group
+	Desc     string        // Short description
+	Args     []string      // Command arguments
+	Aliases  []string      // Command aliases
+	Info     string        // Detailed description
+	Examples []*cmdExample // Usage examples
+}
+
+// cmdExample contains command usage example
+type cmdExample struct {
+	Cmd    string // Command with arguments
+	Result string // Result of command execution
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// commands contains info about all supported commands
+var commands = []*cmdInfo{
+	{
+		Name: CMD_BASENAME, Group: GROUP_BASIC, Aliases: []string{"basename"},
+		Desc: "Strip directory and suffix from filenames",
+		Info: "Prints the last element of path. Trailing slashes are removed before extracting the last element. If path is empty, prints \".\".",
+		Examples: []*cmdExample{
+			{"base /path/to/file.txt", "file.txt"},
+			{"base /path/to/dir/", "dir"},
+		},
+	},
+	{
+		Name: CMD_DIRNAME, Group: GROUP_BASIC, Aliases: []string{"dirname"},
+		Desc: "Strip last component from file name",
+		Info: "Prints all but the last element of path. If path is empty or doesn't contain any slashes, prints \".\".",
+		Examples: []*cmdExample{
+			{"dir /path/to/file.txt", "/path/to"},
+			{"dir file.txt", "."},
+		},
+	},
+	{
+		Name: CMD_DIRNAME_NUM, Group: GROUP_BASIC, Args: []string{"num"},
+		Desc: "Return N elements from path",
+		Info: "Prints first N elements of path. If number is prefixed with ^ or minus, command removes N last elements instead. Paths with less than two separators are printed as is.",
+		Examples: []*cmdExample{
+			{"dirn 2 /path/to/some/file.txt", "/path/to"},
+			{"dirn ^1 /path/to/some/file.txt", "/path/to/some"},
+		},
+	},
+	{
+		Name: CMD_READLINK, Group: GROUP_BASIC, Aliases: []string{"readlink"},
+		Desc: "Print resolved symbolic links or canonical file names",
+		Info: "Prints path with all symbolic links resolved. If path can't be resolved, prints path as is (or returns error in strict mode).",
+		Examples: []*cmdExample{
+			{"link /usr/bin/python3", "/usr/bin/python3.12"},
+		},
+	},
+	{
+		Name: CMD_CLEAN, Group: GROUP_BASIC,
+		Desc: "Print shortest path name equivalent to path by purely lexical processing",
+		Info: "Replaces multiple slashes with a single slash, eliminates . and .. elements and removes trailing slashes. Tilde at the beginning of path is replaced by the current user home directory.",
+		Examples: []*cmdExample{
+			{"clean /path/to/../from//file.txt", "/path/from/file.txt"},
+		},
+	},
+	{
+		Name: CMD_COMPACT, Group: GROUP_BASIC,
+		Desc: "Converts path to compact representation",
+		Info: "Shortens every directory in path to its first letter. The last element of path is printed as is. Options can be passed using compact+option syntax: tilde (replace home directory with tilde first), unique (use the shortest prefix which is unique among directories in the same parent directory), keep=N (keep the last N path elements intact) and len=N (keep N symbols of every directory name). If any option is used, leading dots of directories names are kept (.config becomes .c instead of .).",
+		Examples: []*cmdExample{
+			{"compact /very/long/path/to/some/file.txt", "/v/l/p/t/s/file.txt"},
+			{"compact+tilde /home/user/projects/app/main.go", "~/p/a/main.go"},
+			{"compact+unique /home/user/projects/app", "/h/user/pr/app"},
+			{"compact+keep=2+len=2 /very/long/path/to/file.txt", "/ve/lo/pa/to/file.txt"},
+		},
+	},
+	{
+		Name: CMD_TRUNCATE, Group: GROUP_BASIC, Args: []string{"width"},
+		Desc: "Truncate path to given width",
+		Info: "Replaces middle part of path with ellipsis, so path is no longer than given number of symbols. Path which fits into given width is printed as is.",
+		Examples: []*cmdExample{
+			{"truncate 16 /very/long/path/to/file.txt", "/very/l…file.txt"},
+		},
+	},
+	{
+		Name: CMD_ABS, Group: GROUP_BASIC,
+		Desc: "Print absolute representation of path",
+		Info: "Prints absolute representation of path using current working directory. Path is cleaned by clean command rules.",
+		Examples: []*cmdExample{
+			{"abs ../file.txt", "/home/user/file.txt"},
+		},
+	},
+	{
+		Name: CMD_EXPAND, Group: GROUP_BASIC,
+		Desc: "Expand tilde and environment variables in path",
+		Info: "Expands tilde (~ and ~user) at the start of path and environment variables ($VAR, ${VAR} and ${VAR:-default}). Default value is expanded too. Unset variables are replaced with empty string, with --strict option they are treated as errors.",
+		Examples: []*cmdExample{
+			{"expand '~/data/$PROJECT/file.txt'", "/home/user/data/app/file.txt"},
+			{"expand '${XDG_CACHE_HOME:-~/.cache}/app'", "/home/user/.cache/app"},
+		},
+	},
+	{
+		Name: CMD_TILDE, Group: GROUP_BASIC,
+		Desc: "Replace home directory with tilde",
+		Info: "Replaces home directory ($HOME) at the start of path with tilde. Can be used for displaying paths.",
+		Examples: []*cmdExample{
+			{"tilde /home/user/data/file.txt", "~/data/file.txt"},
+		},
+	},
+	{
+		Name: CMD_EXT, Group: GROUP_BASIC,
+		Desc: "Print file extension",
+		Info: "Prints extension of the last element of path including leading dot. If there is no extension, prints nothing. With --full option compound extensions (like .tar.gz) are printed. In pipeline command accepts optional number of dotted segments to print (segments with digits only, like version numbers, are not treated as extension).",
+		Examples: []*cmdExample{
+			{"ext /path/to/file.txt", ".txt"},
+			{"--full ext /path/to/pkg-1.0.tar.gz", ".tar.gz"},
+			{"ext+3 /path/to/pkg-1.0.tar.gz", ".tar.gz"},
+		},
+	},
+	{
+		Name: CMD_STEM, Group: GROUP_BASIC,
+		Desc: "Print the last element of path without extension",
+		Info: "Prints the last element of path without extension. Trailing slashes are ignored. Dotfiles (like .bashrc) don't have extension, so they are printed as is.",
+		Examples: []*cmdExample{
+			{"stem /path/to/file.txt", "file"},
+			{"stem /home/user/.bashrc", ".bashrc"},
+		},
+	},
+	{
+		Name: CMD_MATCH, Group: GROUP_BASIC, Args: []string{"pattern"},
+		Desc: "Filter given path using pattern",
+		Info: "Prints path only if it matches shell file name pattern. Pattern syntax: * matches any sequence of non-separator characters, ? matches any single non-separator character, [range] matches character in range.",
+		Examples: []*cmdExample{
+			{"match '*.txt' file.txt file.log", "file.txt"},
+		},
+	},
+	{
+		Name: CMD_JOIN, Group: GROUP_BASIC, Args: []string{"root"},
+		Desc: "Join path elements",
+		Info: "Joins root with given path and evaluates all symbolic links. Returns error if final destination is outside root.",
+		Examples: []*cmdExample{
+			{"join /srv/data file.txt", "/srv/data/file.txt"},
+		},
+	},
+	{
+		Name: CMD_SPLIT, Group: GROUP_BASIC,
+		Desc: "Split path into elements",
+		Info: "Prints every element of path as a separate record. Root, empty elements and trailing slashes are ignored. Commands after split in pipeline are applied to every element.",
+		Examples: []*cmdExample{
+			{"-s split /home/user/file.txt", "home user file.txt"},
+		},
+	},
+	{
+		Name: CMD_ELEM, Group: GROUP_BASIC, Args: []string{"index"},
+		Desc: "Print path element with given index",
+		Info: "Prints path element with given index (starting from 0). Negative index (or index with ^ prefix) is counted from the end of path. If index is out of range, nothing is printed (or error is returned in strict mode).",
+		Examples: []*cmdExample{
+			{"elem 1 /home/user/file.txt", "user"},
+			{"elem+-1 /home/user/file.txt", "file.txt"},
+		},
+	},
+	{
+		Name: CMD_SLICE, Group: GROUP_BASIC, Args: []string{"start:end"},
+		Desc: "Print part of path",
+		Info: "Prints path elements from start (inclusive) to end (exclusive) index. Both indexes are optional and can be negative (counted from the end of path). Leading slash is kept if slice starts from the first element of absolute path.",
+		Examples: []*cmdExample{
+			{"slice 1:3 /home/user/john/file.txt", "user/john"},
+			{"slice :-1 /home/user/john/file.txt", "/home/user/john"},
+		},
+	},
+	{
+		Name: CMD_DEPTH, Group: GROUP_BASIC,
+		Desc: "Print number of path elements",
+		Info: "Prints number of path elements. Root, empty elements and trailing slashes are ignored.",
+		Examples: []*cmdExample{
+			{"depth /home/user/file.txt", "3"},
+		},
+	},
+	{
+		Name: CMD_REL, Group: GROUP_BASIC, Args: []string{"base"},
+		Desc: "Print path relative to base",
+		Info: "Prints path relative to base directory using purely lexical processing. Both path and base are converted to absolute form using current working directory before processing. In pair mode command doesn't have arguments and prints the second path relative to the first one.",
+		Examples: []*cmdExample{
+			{"rel /srv/data /srv/www/index.html", "../www/index.html"},
+		},
+	},
+	{
+		Name: CMD_REL_REAL, Group: GROUP_BASIC, Args: []string{"base"},
+		Desc: "Print path relative to base with resolved symbolic links",
+		Info: "Prints path relative to base directory like rel command, but resolves symbolic links in path and base first (like realpath --relative-to). If path can't be resolved, it's used as is (or returns error in strict mode).",
+		Examples: []*cmdExample{
+			{"rel-real /srv/data /srv/www/index.html", "../www/index.html"},
+		},
+	},
+	{
+		Name: CMD_REL_UNDER, Group: GROUP_BASIC, Args: []string{"base"},
+		Desc: "Print path relative to base only if path is under base",
+		Info: "Prints path relative to base directory if path is inside base, otherwise prints absolute path (like realpath --relative-base).",
+		Examples: []*cmdExample{
+			{"rel-under /srv/data /srv/data/file.txt", "file.txt"},
+			{"rel-under /srv/data /srv/www/index.html", "/srv/www/index.html"},
+		},
+	},
+
+	{
+		Name: CMD_MAX_LEN, Group: GROUP_BASIC, Args: []string{"bytes"},
+		Desc: "Filter paths by length",
+		Info: "Prints path only if its length in bytes is less than or equal to given limit.",
+		Examples: []*cmdExample{
+			{"max-len 17 /path/to/file.txt", "/path/to/file.txt"},
+		},
+	},
+	{
+		Name: CMD_MAX_NAME_LEN, Group: GROUP_BASIC, Args: []string{"bytes"},
+		Desc: "Filter paths by length of path elements",
+		Info: "Prints path only if length in bytes of every path element is less than or equal to given limit.",
+		Examples: []*cmdExample{
+			{"max-name-len 8 /path/to/file.txt", "/path/to/file.txt"},
+		},
+	},
+	{
+		Name: CMD_MAX_DEPTH, Group: GROUP_BASIC, Args: []string{"depth"},
+		Desc: "Filter paths by depth",
+		Info: "Prints path only if number of path elements is less than or equal to given limit. See depth command for info about counting path elements.",
+		Examples: []*cmdExample{
+			{"max-depth 3 /path/to/file.txt", "/path/to/file.txt"},
+		},
+	},
+	{
+		Name: CMD_ADD_PREFIX, Group: GROUP_MODIFY, Args: []string{"prefix"},
+		Desc: "Add the substring at the beginning",
+		Info: "Adds given substring at the beginning of path.",
+		Examples: []*cmdExample{
+			{"add-prefix /home/user/ file.txt", "/home/user/file.txt"},
+		},
+	},
+	{
+		Name: CMD_DEL_PREFIX, Group: GROUP_MODIFY, Args: []string{"prefix"},
+		Desc: "Remove the substring at the beginning",
+		Info: "Removes given substring from the beginning of path. If path doesn't start with substring, prints path as is.",
+		Examples: []*cmdExample{
+			{"del-prefix /home/user/ /home/user/file.txt", "file.txt"},
+		},
+	},
+	{
+		Name: CMD_ADD_SUFFIX, Group: GROUP_MODIFY, Args: []string{"suffix"},
+		Desc: "Add the substring at the end",
+		Info: "Adds given substring at the end of path.",
+		Examples: []*cmdExample{
+			{"add-suffix .bak file.txt", "file.txt.bak"},
+		},
+	},
+	{
+		Name: CMD_DEL_SUFFIX, Group: GROUP_MODIFY, Args: []string{"suffix"},
+		Desc: "Remove the substring at the end",
+		Info: "Removes given substring from the end of path. If path doesn't end with substring, prints path as is.",
+		Examples: []*cmdExample{
+			{"del-suffix .bak file.txt.bak", "file.txt"},
+		},
+	},
+	{
+		Name: CMD_EXCLUDE, Group: GROUP_MODIFY, Args: []string{"substr"},
+		Desc: "Exclude part of the path",
+		Info: "Removes all occurrences of given substring from path.",
+		Examples: []*cmdExample{
+			{"exclude _small image_small.jpg", "image.jpg"},
+		},
+	},
+	{
+		Name: CMD_REPLACE, Group: GROUP_MODIFY, Args: []string{"old", "new"},
+		Desc: "Replace part of the path",
+		Info: "Replaces all occurrences of old substring with new substring.",
+		Examples: []*cmdExample{
+			{"replace small 32px image_small.jpg", "image_32px.jpg"},
+		},
+	},
+	{
+		Name: CMD_LOWER, Group: GROUP_MODIFY, Aliases: []string{"lower-case"},
+		Desc: "Convert path to lower case",
+		Info: "Converts all letters in path to lower case using Unicode case mapping rules.",
+		Examples: []*cmdExample{
+			{"lower IMAGE.JPG", "image.jpg"},
+		},
+	},
+	{
+		Name: CMD_UPPER, Group: GROUP_MODIFY, Aliases: []string{"upper-case"},
+		Desc: "Convert path to upper case",
+		Info: "Converts all letters in path to upper case using Unicode case mapping rules.",
+		Examples: []*cmdExample{
+			{"upper image.jpg", "IMAGE.JPG"},
+		},
+	},
+	{
+		Name: CMD_SNAKE, Group: GROUP_MODIFY, Aliases: []string{"snake-case"},
+		Desc: "Convert file name to snake case",
+		Info: "Splits file name (without extension) into words and joins them in lower case using underscore. Words are separated by any non-alphanumeric symbols and case changes. Directory and extension are kept as is, use each command to convert all path elements.",
+		Examples: []*cmdExample{
+			{"snake '/path/to/My Report 2024.PDF'", "/path/to/my_report_2024.PDF"},
+		},
+	},
+	{
+		Name: CMD_KEBAB, Group: GROUP_MODIFY, Aliases: []string{"kebab-case"},
+		Desc: "Convert file name to kebab case",
+		Info: "Splits file name (without extension) into words and joins them in lower case using hyphen. Directory and extension are kept as is.",
+		Examples: []*cmdExample{
+			{"kebab /path/to/myReportFile.txt", "/path/to/my-report-file.txt"},
+		},
+	},
+	{
+		Name: CMD_CAMEL, Group: GROUP_MODIFY, Aliases: []string{"camel-case"},
+		Desc: "Convert file name to camel case",
+		Info: "Splits file name (without extension) into words and joins them capitalizing all words except the first one. Directory and extension are kept as is.",
+		Examples: []*cmdExample{
+			{"camel /path/to/my_report_file.txt", "/path/to/myReportFile.txt"},
+		},
+	},
+	{
+		Name: CMD_PASCAL, Group: GROUP_MODIFY, Aliases: []string{"pascal-case"},
+		Desc: "Convert file name to pascal case",
+		Info: "Splits file name (without extension) into words and joins them capitalizing all words. Directory and extension are kept as is.",
+		Examples: []*cmdExample{
+			{"pascal /path/to/my_report_file.txt", "/path/to/MyReportFile.txt"},
+		},
+	},
+	{
+		Name: CMD_TITLE, Group: GROUP_MODIFY, Aliases: []string{"title-case"},
+		Desc: "Convert file name to title case",
+		Info: "Splits file name (without extension) into words and joins them capitalizing all words using space. Directory and extension are kept as is.",
+		Examples: []*cmdExample{
+			{"title /path/to/my_report_file.txt", "/path/to/My Report File.txt"},
+		},
+	},
+	{
+		Name: CMD_SLUG, Group: GROUP_MODIFY,
+		Desc: "Convert file name to slug",
+		Info: "Transliterates file name (without extension) to ASCII, removes all unsafe symbols and joins words in lower case using hyphen. Directory and extension are kept as is.",
+		Examples: []*cmdExample{
+			{"slug '/path/to/Отчёт за 2024 год!.pdf'", "/path/to/otchyot-za-2024-god.pdf"},
+		},
+	},
+	{
+		Name: CMD_NFC, Group: GROUP_MODIFY,
+		Desc: "Normalize path to Unicode NFC form",
+		Info: "Converts path to Unicode normalization form C (canonical composition). This form is used by most Linux and Windows applications.",
+		Examples: []*cmdExample{
+			{"nfc /path/to/Cafe\u0301.txt", "/path/to/Café.txt"},
+		},
+	},
+	{
+		Name: CMD_NFD, Group: GROUP_MODIFY,
+		Desc: "Normalize path to Unicode NFD form",
+		Info: "Converts path to Unicode normalization form D (canonical decomposition). This form is used for file names by macOS.",
+		Examples: []*cmdExample{
+			{"nfd /path/to/Café.txt", "/path/to/Cafe\u0301.txt"},
+		},
+	},
+	{
+		Name: CMD_NFKC, Group: GROUP_MODIFY,
+		Desc: "Normalize path to Unicode NFKC form",
+		Info: "Converts path to Unicode normalization form KC (compatibility composition). Compatibility characters (like ligatures or full-width letters) are replaced by their ordinary equivalents.",
+		Examples: []*cmdExample{
+			{"nfkc /path/to/ﬁle.txt", "/path/to/file.txt"},
+		},
+	},
+	{
+		Name: CMD_FOLD, Group: GROUP_MODIFY, Aliases: []string{"case-fold"},
+		Desc: "Fold case of path",
+		Info: "Converts path using Unicode case folding. Folded paths can be used for case-insensitive comparison.",
+		Examples: []*cmdExample{
+			{"fold /path/to/Straße.TXT", "/path/to/strasse.txt"},
+		},
+	},
+	{
+		Name: CMD_SANITIZE, Group: GROUP_MODIFY, Args: []string{"profile"},
+		Desc: "Convert path to portable form",
+		Info: "Replaces symbols which can't be used in names on target system with underscore, renames reserved names (like CON or NUL), removes forbidden trailing symbols and truncates too long names (longer than 255 bytes, or 255 UTF-16 code units for windows and fat32 profiles) keeping extension. Supported profiles: posix-portable, windows, fat32, s3.",
+		Examples: []*cmdExample{
+			{"sanitize windows 'data/CON.txt'", "data/CON_.txt"},
+			{"sanitize windows 'data/report: final?.txt'", "data/report_ final_.txt"},
+		},
+	},
+	{
+		Name: CMD_QUOTE, Group: GROUP_MODIFY, Args: []string{"style"},
+		Desc: "Quote path for using in shell",
+		Info: "Quotes path if it contains symbols with special meaning for shell. Supported styles: sh (POSIX single quotes), bash (like sh, but uses $'…' for paths with control symbols), fish and powershell.",
+		Examples: []*cmdExample{
+			{"quote sh \"/path/to/john's file.txt\"", "'/path/to/john'\\''s file.txt'"},
+			{"quote powershell \"/path/to/john's file.txt\"", "'/path/to/john''s file.txt'"},
+		},
+	},
+	{
+		Name: CMD_UNQUOTE, Group: GROUP_MODIFY,
+		Desc: "Remove shell quoting from path",
+		Info: "Removes quotes and escape sequences from path. By default sh and bash quoting rules are used, in pipeline command accepts optional quoting style (sh, bash, fish or powershell).",
+		Examples: []*cmdExample{
+			{"unquote \"'/path/to/my file.txt'\"", "/path/to/my file.txt"},
+			{"unquote+powershell \"'/path/to/john''s file.txt'\"", "/path/to/john's file.txt"},
+		},
+	},
+	{
+		Name: CMD_ESCAPE, Group: GROUP_MODIFY,
+		Desc: "Escape non-printable symbols",
+		Info: "Replaces control symbols, non-printable Unicode symbols and invalid UTF-8 sequences with escape sequences (\\n, \\t, \\x1b, \\u200e…) like ls -b. Backslash is escaped as \\\\.",
+		Examples: []*cmdExample{
+			{"escape $'/path/to/new\\nfile.txt'", "/path/to/new\\nfile.txt"},
+		},
+	},
+	{
+		Name: CMD_UNESCAPE, Group: GROUP_MODIFY,
+		Desc: "Convert escape sequences back to symbols",
+		Info: "Converts escape sequences created by escape command back to symbols.",
+		Examples: []*cmdExample{
+			{"unescape '/path/to/new\\nfile.txt'", "/path/to/new↵file.txt"},
+		},
+	},
+	{
+		Name: CMD_TO_URI, Group: GROUP_MODIFY,
+		Desc: "Convert path to file URI",
+		Info: "Converts path to absolute form and then to file URI. All symbols which can't be used in URI (spaces, non-ASCII symbols…) are percent-encoded. Path in //host/path format is converted to URI with remote host.",
+		Examples: []*cmdExample{
+			{"to-uri '/path/to/my file.txt'", "file:///path/to/my%20file.txt"},
+			{"to-uri //server/share/file.txt", "file://server/share/file.txt"},
+		},
+	},
+	{
+		Name: CMD_FROM_URI, Group: GROUP_MODIFY,
+		Desc: "Convert file URI to path",
+		Info: "Converts file URI to path decoding all percent-encoded symbols. URI with remote host is converted to path in //host/path format. Returns error for URIs with other schemes or with encoded slashes (%2F) in path.",
+		Examples: []*cmdExample{
+			{"from-uri file:///path/to/my%20file.txt", "/path/to/my file.txt"},
+			{"from-uri file://server/share/file.txt", "//server/share/file.txt"},
+		},
+	},
+	{
+		Name: CMD_URL_ENCODE, Group: GROUP_MODIFY,
+		Desc: "Encode path using percent-encoding",
+		Info: "Encodes every path element using percent-encoding, so path can be used in URL. Slashes between path elements are kept as is.",
+		Examples: []*cmdExample{
+			{"url-encode '/path/to/отчёт 1.txt'", "/path/to/%D0%BE%D1%82%D1%87%D1%91%D1%82%201.txt"},
+		},
+	},
+	{
+		Name: CMD_URL_DECODE, Group: GROUP_MODIFY,
+		Desc: "Decode percent-encoded path",
+		Info: "Decodes all percent-encoded symbols in path. Encoded slashes (%2F) are kept as is, because they are a part of path element (in strict mode such paths are treated as errors). Plus sign isn't converted to space.",
+		Examples: []*cmdExample{
+			{"url-decode /path/to/my%20file.txt", "/path/to/my file.txt"},
+		},
+	},
+	{
+		Name: CMD_TO_POSIX, Group: GROUP_MODIFY,
+		Desc: "Convert Windows path to path with forward slashes",
+		Info: "Replaces all backslashes with forward slashes and removes \\\\?\\ prefix. Note that on POSIX systems backslash is a valid symbol of file name.",
+		Examples: []*cmdExample{
+			{"to-posix 'C:\\Users\\bob\\file.txt'", "C:/Users/bob/file.txt"},
+			{"to-posix '\\\\?\\UNC\\server\\share\\file.txt'", "//server/share/file.txt"},
+		},
+	},
+	{
+		Name: CMD_TO_WINDOWS, Group: GROUP_MODIFY,
+		Desc: "Convert path to Windows path",
+		Info: "Replaces all forward slashes with backslashes. WSL (/mnt/c/…) and Cygwin (/cygdrive/c/…) paths are converted to paths with drive letter.",
+		Examples: []*cmdExample{
+			{"to-windows /mnt/c/Users/bob/file.txt", "C:\\Users\\bob\\file.txt"},
+			{"to-windows /cygdrive/d/data", "D:\\data"},
+		},
+	},
+	{
+		Name: CMD_TO_WSL, Group: GROUP_MODIFY,
+		Desc: "Convert Windows path to WSL path",
+		Info: "Converts path with drive letter to path in /mnt directory used by WSL. Paths without drive letter are converted to paths with forward slashes.",
+		Examples: []*cmdExample{
+			{"to-wsl 'C:\\Users\\bob\\file.txt'", "/mnt/c/Users/bob/file.txt"},
+		},
+	},
+	{
+		Name: CMD_TO_CYGWIN, Group: GROUP_MODIFY,
+		Desc: "Convert Windows path to Cygwin path",
+		Info: "Converts path with drive letter to path in /cygdrive directory used by Cygwin. Paths without drive letter are converted to paths with forward slashes.",
+		Examples: []*cmdExample{
+			{"to-cygwin 'C:\\Users\\bob\\file.txt'", "/cygdrive/c/Users/bob/file.txt"},
+		},
+	},
+	{
+		Name: CMD_STRIP_EXT, Group: GROUP_MODIFY,
+		Desc: "Remove file extension",
+		Info: "Removes extension of the last element of path. If there is no extension, prints path as is. With --full option compound extensions (like .tar.gz) are removed.",
+		Examples: []*cmdExample{
+			{"strip-ext /path/to/file.txt", "/path/to/file"},
+			{"--full strip-ext /path/to/pkg-1.0.tar.gz", "/path/to/pkg-1.0"},
+		},
+	},
+	{
+		Name: CMD_SET_EXT, Group: GROUP_MODIFY, Args: []string{"ext"},
+		Desc: "Replace file extension",
+		Info: "Replaces extension of the last element of path with given one (leading dot is optional). If there is no extension, adds given one. Empty extension removes current extension. Dotfiles (like .bashrc) don't have extension.",
+		Examples: []*cmdExample{
+			{"set-ext .md /path/to/file.txt", "/path/to/file.md"},
+			{"set-ext bak /home/user/.bashrc", "/home/user/.bashrc.bak"},
+		},
+	},
+	{
+		Name: CMD_SET_BASE, Group: GROUP_MODIFY, Args: []string{"name"},
+		Desc: "Replace the last element of path",
+		Info: "Replaces the last element of path with given name. Trailing slashes are kept as is.",
+		Examples: []*cmdExample{
+			{"set-base README.md /path/to/file.txt", "/path/to/README.md"},
+		},
+	},
+	{
+		Name: CMD_SET_DIR, Group: GROUP_MODIFY, Args: []string{"dir"},
+		Desc: "Replace parent directory",
+		Info: "Replaces parent directory of the last element of path with given directory. Empty directory removes parent directory.",
+		Examples: []*cmdExample{
+			{"set-dir /srv/data /path/to/file.txt", "/srv/data/file.txt"},
+		},
+	},
+	{
+		Name: CMD_EACH, Group: GROUP_MODIFY, Args: []string{"command"}, Aliases: []string{"map-components"},
+		Desc: "Apply command to every path element",
+		Info: "Splits path into elements, applies given command (with its arguments) to every element and joins elements back. Separators are kept as is. If command returns empty result for element, this element is removed from path. Use several each stages in pipeline to apply more than one command.",
+		Examples: []*cmdExample{
+			{"each lower /Home/User/File.TXT", "/home/user/file.txt"},
+			{"each+replace+_+- /my_dir/my_file.txt", "/my-dir/my-file.txt"},
+		},
+	},
+	{
+		Name: CMD_EACH_DIR, Group: GROUP_MODIFY, Args: []string{"command"},
+		Desc: "Apply command to every directory in path",
+		Info: "Works like each command, but applies given command only to directories (all path elements except the last one).",
+		Examples: []*cmdExample{
+			{"each-dir upper /home/user/file.txt", "/HOME/USER/file.txt"},
+		},
+	},
+	{
+		Name: CMD_EACH_BASE, Group: GROUP_MODIFY, Args: []string{"command"},
+		Desc: "Apply command to the last element of path",
+		Info: "Works like each command, but applies given command only to the last path element.",
+		Examples: []*cmdExample{
+			{"each-base upper /home/user/file.txt", "/home/user/FILE.TXT"},
+		},
+	},
+
+	{
+		Name: CMD_IS_ABS, Group: GROUP_PREDICATES,
+		Desc: "Check if given path is absolute",
+		Info: "Exits with non-zero exit code if any of given paths is not absolute.",
+		Examples: []*cmdExample{
+			{"is-abs /path/to/file.txt", "exit code 0"},
+		},
+	},
+	{
+		Name: CMD_IS_LOCAL, Group: GROUP_PREDICATES,
+		Desc: "Check if given path is local",
+		Info: "Exits with non-zero exit code if any of given paths is not local. Local path is relative path which doesn't escape the current directory (e.g. doesn't start with ..).",
+		Examples: []*cmdExample{
+			{"is-local ../file.txt", "exit code 1"},
+		},
+	},
+	{
+		Name: CMD_IS_SAFE, Group: GROUP_PREDICATES,
+		Desc: "Check if given path is safe",
+		Info: "Exits with non-zero exit code if any of given paths is not safe. Unsafe path is a path which points to the root or system directory (/etc, /usr/bin, /var/lib…).",
+		Examples: []*cmdExample{
+			{"is-safe /etc/passwd", "exit code 1"},
+		},
+	},
+	{
+		Name: CMD_IS_MATCH, Group: GROUP_PREDICATES, Args: []string{"pattern"},
+		Desc: "Check if given path is match to pattern",
+		Info: "Exits with non-zero exit code if any of given paths doesn't match shell file name pattern. See match command for pattern syntax.",
+		Examples: []*cmdExample{
+			{"is-match '*.txt' file.txt", "exit code 0"},
+		},
+	},
+	{
+		Name: CMD_IS_PORTABLE, Group: GROUP_PREDICATES, Args: []string{"profile"},
+		Desc: "Check if given path can be used on target system",
+		Info: "Exits with non-zero exit code if any of given paths can't be used on target system without changes. With --trace option prints violated rule. See sanitize command for supported profiles.",
+		Examples: []*cmdExample{
+			{"is-portable windows data/CON.txt", "exit code 1"},
+		},
+	},
+	{
+		Name: CMD_IS_WITHIN, Group: GROUP_PREDICATES, Args: []string{"profile"},
+		Desc: "Check if given path is within length limits",
+		Info: "Exits with non-zero exit code if any of given paths exceeds length limits of target system. Supported profiles: linux (PATH_MAX and NAME_MAX in bytes), ustar (name and prefix limits of tar archives), windows-legacy-260 (MAX_PATH in UTF-16 code units). With --trace option prints exceeded limit.",
+		Examples: []*cmdExample{
+			{"is-within-limits ustar /path/to/file.txt", "exit code 0"},
+		},
+	},
+	{
+		Name: CMD_IS_NORMALIZED, Group: GROUP_PREDICATES, Args: []string{"form"},
+		Desc: "Check if given path is in Unicode normalization form",
+		Info: "Exits with non-zero exit code if any of given paths is not in given Unicode normalization form (nfc, nfd, nfkc or nfkd).",
+		Examples: []*cmdExample{
+			{"is-normalized nfc /path/to/file.txt", "exit code 0"},
+		},
+	},
+
+	{
+		Name: CMD_SAME_FILE, Group: GROUP_PAIR,
+		Desc: "Check if both paths point to the same file",
+		Info: "Exits with non-zero exit code if paths in any pair don't point to the same file (same device and inode). Paths which don't exist are treated as different files (or returns error in strict mode). Works only in pair mode.",
+		Examples: []*cmdExample{
+			{"--pair same-file /usr/bin/python3 /usr/bin/python3.12", "exit code 0"},
+		},
+	},
+	{
+		Name: CMD_COMMON, Group: GROUP_PAIR,
+		Desc: "Print the longest common ancestor of both paths",
+		Info: "Prints the longest common ancestor of two paths using purely lexical processing. If one path is absolute and other is relative, both paths are converted to absolute form. Works only in pair mode.",
+		Examples: []*cmdExample{
+			{"--pair common /srv/data/a.txt /srv/www/b.txt", "/srv"},
+		},
+	},
+	{
+		Name: CMD_IS_UNDER, Group: GROUP_PAIR,
+		Desc: "Check if the first path is located under the second path",
+		Info: "Exits with non-zero exit code if the first path in any pair isn't located inside the second path (or isn't equal to it). Check is performed using purely lexical processing. Works only in pair mode.",
+		Examples: []*cmdExample{
+			{"--pair is-under /srv/data/a.txt /srv", "exit code 0"},
+		},
+	},
+
+	{
+		Name: SCOPE_PREFIX + SCOPE_BASE + ":", Group: GROUP_SCOPES, Args: []string{"command"},
+		Desc: "Apply command to the last element of path",
+		Info: "Applies given command (with its arguments) only to the last element of path and assembles path back. Command can be passed right after the colon (@base:command) or as the next argument. Predicates check only the last element of path.",
+		Examples: []*cmdExample{
+			{"@base:upper /path/to/file.txt", "/path/to/FILE.TXT"},
+		},
+	},
+	{
+		Name: SCOPE_PREFIX + SCOPE_STEM + ":", Group: GROUP_SCOPES, Args: []string{"command"},
+		Desc: "Apply command to the last element of path without extension",
+		Info: "Applies given command (with its arguments) only to the last element of path without extension and assembles path back. Command can be passed right after the colon (@stem:command) or as the next argument.",
+		Examples: []*cmdExample{
+			{"'@stem:add-suffix+_old' /path/to/file.txt", "/path/to/file_old.txt"},
+		},
+	},
+	{
+		Name: SCOPE_PREFIX + SCOPE_DIR + ":", Group: GROUP_SCOPES, Args: []string{"command"},
+		Desc: "Apply command to parent directory",
+		Info: "Applies given command (with its arguments) only to parent directory of the last element of path and assembles path back. Command can be passed right after the colon (@dir:command) or as the next argument.",
+		Examples: []*cmdExample{
+			{"'@dir:replace+src+dst' src/lib/src.go", "dst/lib/src.go"},
+		},
+	},
+	{
+		Name: SCOPE_PREFIX + SCOPE_EXT + ":", Group: GROUP_SCOPES, Args: []string{"command"},
+		Desc: "Apply command to file extension",
+		Info: "Applies given command (with its arguments) only to extension of the last element of path (with leading dot) and assembles path back. Command can be passed right after the colon (@ext:command) or as the next argument. Paths without extension are printed as is.",
+		Examples: []*cmdExample{
+			{"@ext:lower IMAGE.JPG", "IMAGE.jpg"},
+		},
+	},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getCommandInfo returns info about command with given name or alias
+func getCommandInfo(name string) *cmdInfo {
+	for _, c := range commands {
+		if c.Name == name || slices.Contains(c.Aliases, name) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// getCommandName returns name of built-in command for given name or alias (case
+// insensitive). Names of plugins are returned as is, because they are case-sensitive.
+func getCommandName(name string) string {
+	info := getCommandInfo(strings.ToLower(name))
+
+	if info == nil {
+		return name
+	}
+
+	return info.Name
+}
+
+// suggestCommands returns names of commands which are similar to given name
+func suggestCommands(name string) []string {
+	var names, result []string
+
+	for _, c := range commands {
+		names = append(names, c.Name)
+		names = append(names, c.Aliases...)
+	}
+
+	names = append(names, listPlugins()...)
+	threshold := max(1, min(3, len(name)/3))
+
+	for dist := 1; dist <= threshold; dist++ {
+		for _, n := range names {
+			if spellcheck.Distance(n, name) == dist && !slices.Contains(result, n) {
+				result = append(result, n)
+			}
+		}
+	}
+
+	if len(result) > 3 {
+		result = result[:3]
+	}
+
+	return result
+}
+
+// unknownCommandError returns error about unknown command with suggestions
+func unknownCommandError(name string) error {
+	suggestions := suggestCommands(name)
+
+	if len(suggestions) == 0 {
+		return usageError("Unknown command %q", name)
+	}
+
+	return usageError(
+		"Unknown command %q. Did you mean %s?",
+		name, `"`+strings.Join(suggestions, `" or "`)+`"`,
+	)
+}
+
+// printCommandHelp prints detailed info about command
+func printCommandHelp(name string) int {
+	info := getCommandInfo(strings.ToLower(name))
+
+	if info == nil {
+		pluginPath := findPlugin(name)
+
+		if pluginPath == "" {
+			printError(unknownCommandError(name).Error())
+			return EC_USAGE
+		}
+
+		fmtc.NewLine()
+		fmtc.Printfn("{*}Usage:{!} %s {y}%s{!} {s-}?args… ?path…{!}", APP, name)
+		fmtc.NewLine()
+		fmtc.Printfn("External command provided by plugin {s-}(%s){!}", pluginPath)
+		fmtc.NewLine()
+
+		return EC_OK
+	}
+
+	fmtc.NewLine()
+	fmtc.Printf("{*}Usage:{!} %s {y}%s{!}", APP, info.Name)
+
+	for _, arg := range info.Args {
+		fmtc.Printf(" {#244}%s{!}", arg)
+	}
+
+	fmtc.Printfn(" {s-}?path…{!}")
+	fmtc.NewLine()
+	fmtc.Printfn("{*}%s{!}", info.Desc)
+
+	if info.Info != "" {
+		fmtc.NewLine()
+		fmtc.Println(fmtutil.Wrap(info.Info, "", 88))
+	}
+
+	if len(info.Args) != 0 {
+		fmtc.NewLine()
+		fmtc.Printfn(
+			"In pipeline arguments are separated by plus: {s}%s+%s{!}",
+			info.Name, strings.Join(info.Args, "+"),
+		)
+	}
+
+	if len(info.Aliases) != 0 {
+		fmtc.NewLine()
+		fmtc.Printfn("{*}Aliases:{!} %s", strings.Join(info.Aliases, ", "))
+	}
+
+	if len(info.Examples) != 0 {
+		fmtc.NewLine()
+		fmtc.Println("{*}Examples{!}")
+
+		for _, e := range info.Examples {
+			fmtc.NewLine()
+			fmtc.Printfn("  %s %s", APP, e.Cmd)
+			fmtc.Printfn("  {&}{s-}→ %s{!}", e.Result)
+		}
+	}
+
+	fmtc.NewLine()
+
+	return EC_OK
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
@@ -0,0 +1,138 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Limits profiles
+const (
+	LIMITS_LINUX   = "linux"
+	LIMITS_USTAR   = "ustar"
+	LIMITS_WINDOWS = "windows-legacy-260"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// limitsChecker is a function which checks path and returns description of
+// exceeded limit
+type limitsChecker func(data string) string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// limitsProfiles contains all supported limits profiles
+var limitsProfiles = map[string]limitsChecker{
+	LIMITS_LINUX:   checkLinuxLimits,
+	LIMITS_USTAR:   checkUstarLimits,
+	LIMITS_WINDOWS: checkWindowsLimits,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getLimitsChecker returns checker for limits profile with given name
+func getLimitsChecker(name string) (limitsChecker, error) {
+	checker, ok := limitsProfiles[strings.ToLower(name)]
+
+	if !ok {
+		var names []string
+
+		for n := range limitsProfiles {
+			names = append(names, n)
+		}
+
+		sort.Strings(names)
+
+		return nil, fmt.Errorf(
+			"Unknown limits profile %q (supported profiles: %s)",
+			name, strings.Join(names, ", "),
+		)
+	}
+
+	return checker, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkLinuxLimits checks path using PATH_MAX (4096 bytes with trailing NUL) and
+// NAME_MAX (255 bytes) limits
+func checkLinuxLimits(data string) string {
+	if len(data) > 4095 {
+		return fmt.Sprintf("path is %d bytes long (limit: 4095)", len(data))
+	}
+
+	return checkNamesLen(data, 255, func(s string) int { return len(s) }, "bytes")
+}
+
+// checkUstarLimits checks path using limits of ustar archive format (name up to 100
+// bytes with optional prefix up to 155 bytes)
+func checkUstarLimits(data string) string {
+	data = strings.TrimRight(data, "/")
+
+	if len(data) <= 100 {
+		return ""
+	}
+
+	if len(data) > 256 {
+		return fmt.Sprintf("path is %d bytes long (limit: 256)", len(data))
+	}
+
+	// Path must be split by slash into prefix and name
+	for i := 0; i < len(data); i++ {
+		if data[i] == '/' && i <= 155 && len(data)-i-1 <= 100 {
+			return ""
+		}
+	}
+
+	return "path can't be split into prefix (up to 155 bytes) and name (up to 100 bytes)"
+}
+
+// checkWindowsLimits checks path using MAX_PATH (260 UTF-16 code units with
+// trailing NUL) and component length (255 UTF-16 code units) limits
+func checkWindowsLimits(data string) string {
+	size := utf16Len(data)
+
+	if size > 259 {
+		return fmt.Sprintf("path is %d UTF-16 code units long (limit: 259)", size)
+	}
+
+	return checkNamesLen(data, 255, utf16Len, "UTF-16 code units")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkNamesLen checks length of every path element
+func checkNamesLen(data string, limit int, lenFunc func(s string) int, unit string) string {
+	for _, name := range strings.Split(data, "/") {
+		size := lenFunc(name)
+
+		if size > limit {
+			return fmt.Sprintf("%q is %d %s long (limit: %d)", name, size, unit, limit)
+		}
+	}
+
+	return ""
+}
+
+// utf16Len returns length of string in UTF-16 code units
+func utf16Len(data string) int {
+	var result int
+
+	for _, r := range data {
+		result += utf16.RuneLen(r)
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
@@ -0,0 +1,214 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/options"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PAIR_SEP is separator used for storing pair of paths in one record. NUL can't
+// be used in paths, so it's safe to use it as a separator.
+const PAIR_SEP = "\x00"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// pairCommands contains commands which work with pair of paths
+var pairCommands = map[string]bool{
+	CMD_REL:       true,
+	CMD_SAME_FILE: true,
+	CMD_COMMON:    true,
+	CMD_IS_UNDER:  true,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newPairHandler creates handler for command which can be used only in pair mode
+func newPairHandler(fn handlerFunc, cmd string, args options.Arguments) (*handler, []string, error) {
+	if !pairMode {
+		return nil, nil, usageError("Command %q can be used only in pair mode", cmd)
+	}
+
+	return &handler{fn, nil, cmd}, args.Strings(), nil
+}
+
+// makePair creates record with pair of paths
+func makePair(a, b string) string {
+	return a + PAIR_SEP + b
+}
+
+// isPair returns true if record contains pair of paths
+func isPair(data string) bool {
+	return pairMode && strings.Contains(data, PAIR_SEP)
+}
+
+// checkNUL returns error if decoded path contains NUL symbol. Such path can't
+// exist and can't be distinguished from pair.
+func checkNUL(data string) error {
+	if strings.Contains(data, PAIR_SEP) {
+		return fmt.Errorf("Result contains NUL symbol")
+	}
+
+	return nil
+}
+
+// splitPair splits record into pair of paths
+func splitPair(data string) (string, string, error) {
+	a, b, ok := strings.Cut(data, PAIR_SEP)
+
+	if !ok {
+		return "", "", fmt.Errorf("Command requires pair of paths")
+	}
+
+	return a, b, nil
+}
+
+// parsePair converts record with two paths separated by tab to pair
+func parsePair(data string) (string, error) {
+	a, b, ok := strings.Cut(data, "\t")
+
+	if !ok {
+		return "", fmt.Errorf("Record must contain two paths separated by tab")
+	}
+
+	return makePair(a, b), nil
+}
+
+// readPair reads pair of paths from reader
+func readPair(r *bufio.Reader, record string) (string, error) {
+	if separator != "\x00" {
+		return parsePair(record)
+	}
+
+	second, err := readRecord(r)
+
+	if err != nil {
+		return "", fmt.Errorf("Record doesn't contain second path")
+	}
+
+	return makePair(record, second), nil
+}
+
+// formatRecord formats record for output
+func formatRecord(data string) string {
+	if !isPair(data) {
+		return data
+	}
+
+	if separator == "\x00" {
+		return data
+	}
+
+	return strings.Replace(data, PAIR_SEP, "\t", 1)
+}
+
+// formatOutput formats record for printing to stdout. If safe is true, record
+// is escaped.
+func formatOutput(data string, safe bool) string {
+	if !safe {
+		return formatRecord(data)
+	}
+
+	return escapeRecord(data)
+}
+
+// escapeRecord formats record escaping non-printable symbols and backslashes
+// in every path
+func escapeRecord(data string) string {
+	if !isPair(data) {
+		return escapeString(data)
+	}
+
+	a, b, _ := splitPair(data)
+
+	return formatRecord(makePair(escapeString(a), escapeString(b)))
+}
+
+// executePairHandler executes handler for every path in pair. Predicates must
+// be true for both paths.
+func executePairHandler(h *handler, data string) (string, error, bool) {
+	a, b, _ := splitPair(data)
+
+	a, err, ok := executeHandler(h, a)
+
+	if err != nil || !ok {
+		return "", err, ok
+	}
+
+	b, err, ok = executeHandler(h, b)
+
+	if err != nil || !ok {
+		return "", err, ok
+	}
+
+	if a == "" || b == "" {
+		return "", nil, true
+	}
+
+	return makePair(a, b), nil, true
+}
+
+// processZippedData runs commands over pairs of records from two files
+func processZippedData(cmds pipe, file1, file2 string) (error, bool) {
+	var readers []*bufio.Reader
+
+	for _, file := range []string{file1, file2} {
+		if file == "-" {
+			readers = append(readers, bufio.NewReader(os.Stdin))
+			continue
+		}
+
+		fd, err := os.Open(file)
+
+		if err != nil {
+			return ioError("Can't open input file: %v", err), false
+		}
+
+		defer fd.Close()
+
+		readers = append(readers, bufio.NewReader(fd))
+	}
+
+	for num := 1; ; num++ {
+		a, err1 := readRecord(readers[0])
+		b, err2 := readRecord(readers[1])
+
+		switch {
+		case err1 == io.EOF && err2 == io.EOF:
+			return nil, true
+		case err1 != nil && err1 != io.EOF:
+			return ioError("Can't read data from %s: %v", file1, err1), false
+		case err2 != nil && err2 != io.EOF:
+			return ioError("Can't read data from %s: %v", file2, err2), false
+		case err1 == io.EOF || err2 == io.EOF:
+			return sourceError(
+				fmt.Errorf("Files %s and %s have different number of records", file1, file2),
+				file1, num,
+			), false
+		}
+
+		err, ok := processData(cmds, makePair(a, b))
+
+		if err != nil {
+			return sourceError(err, file1, num), false
+		}
+
+		if !ok {
+			return nil, false
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
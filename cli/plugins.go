@@ -0,0 +1,188 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/options"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PLUGIN_PREFIX is prefix of plugin executable names
+const PLUGIN_PREFIX = APP + "-"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// plugin contains info about external command
+type plugin struct {
+	Name string // Command name
+	Path string // Path to plugin executable
+
+	cmd    *exec.Cmd
+	input  io.WriteCloser
+	output *bufio.Reader
+	delim  byte
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startedPlugins is a slice with all started plugins
+var startedPlugins []*plugin
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// findPlugin returns path to plugin executable for given command
+func findPlugin(cmd string) string {
+	if cmd == "" || strings.ContainsAny(cmd, `/\`) {
+		return ""
+	}
+
+	binPath, err := exec.LookPath(PLUGIN_PREFIX + cmd)
+
+	if err != nil {
+		return ""
+	}
+
+	return binPath
+}
+
+// listPlugins returns names of all plugins available in $PATH
+func listPlugins() []string {
+	var result []string
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		files, err := os.ReadDir(dir)
+
+		if err != nil {
+			continue
+		}
+
+		for _, file := range files {
+			name, ok := strings.CutPrefix(file.Name(), PLUGIN_PREFIX)
+
+			if !ok || name == "" || slices.Contains(result, name) {
+				continue
+			}
+
+			info, err := file.Info()
+
+			if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+				continue
+			}
+
+			result = append(result, name)
+		}
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// stopPlugins stops all started plugins
+func stopPlugins() {
+	for _, p := range startedPlugins {
+		p.Stop()
+	}
+
+	startedPlugins = nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Handle is handler for plugin command
+func (p *plugin) Handle(data string, args options.Arguments) (string, error, bool) {
+	if p.cmd == nil {
+		err := p.start(args)
+
+		if err != nil {
+			return "", err, false
+		}
+	}
+
+	_, err := p.input.Write(append([]byte(data), p.delim))
+
+	if err != nil {
+		return "", fmt.Errorf("Can't send data to plugin %q: %v", p.Name, err), false
+	}
+
+	result, err := p.output.ReadString(p.delim)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't read data from plugin %q: %v", p.Name, err), false
+	}
+
+	return strings.TrimSuffix(result, string(p.delim)), nil, true
+}
+
+// Stop closes plugin input and waits until plugin exits
+func (p *plugin) Stop() error {
+	if p.cmd == nil {
+		return nil
+	}
+
+	p.input.Close()
+	err := p.cmd.Wait()
+	p.cmd = nil
+
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// start starts plugin process
+func (p *plugin) start(args options.Arguments) error {
+	cmd := exec.Command(p.Path, args.Strings()...)
+	cmd.Stderr = os.Stderr
+	delim := byte('\n')
+
+	if separator == "\x00" {
+		delim = 0
+		cmd.Env = append(os.Environ(), "PATH_PLUGIN_PROTOCOL=nul")
+	} else {
+		cmd.Env = append(os.Environ(), "PATH_PLUGIN_PROTOCOL=line")
+	}
+
+	input, err := cmd.StdinPipe()
+
+	if err != nil {
+		return fmt.Errorf("Can't create input pipe for plugin %q: %v", p.Name, err)
+	}
+
+	output, err := cmd.StdoutPipe()
+
+	if err != nil {
+		input.Close()
+		return fmt.Errorf("Can't create output pipe for plugin %q: %v", p.Name, err)
+	}
+
+	err = cmd.Start()
+
+	if err != nil {
+		input.Close()
+		output.Close()
+		return fmt.Errorf("Can't start plugin %q: %v", p.Name, err)
+	}
+
+	p.cmd, p.input, p.output, p.delim = cmd, input, bufio.NewReader(output), delim
+
+	startedPlugins = append(startedPlugins, p)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
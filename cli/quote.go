@@ -0,0 +1,336 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Quoting styles
+const (
+	QUOTE_SH         = "sh"
+	QUOTE_BASH       = "bash"
+	QUOTE_FISH       = "fish"
+	QUOTE_POWERSHELL = "powershell"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// quoteStyles contains all supported quoting styles
+var quoteStyles = []string{QUOTE_SH, QUOTE_BASH, QUOTE_FISH, QUOTE_POWERSHELL}
+
+// ansiEscapes contains escape sequences used in ANSI-C quoting
+var ansiEscapes = map[rune]string{
+	'\a': `\a`, '\b': `\b`, '\t': `\t`, '\n': `\n`, '\v': `\v`,
+	'\f': `\f`, '\r': `\r`, 0x1B: `\e`, '\\': `\\`, '\'': `\'`,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getQuoteStyle returns normalized name of quoting style
+func getQuoteStyle(name string) (string, error) {
+	name = strings.ToLower(name)
+
+	for _, style := range quoteStyles {
+		if style == name {
+			return style, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"Unsupported quoting style %q (supported styles: %s)",
+		name, strings.Join(quoteStyles, ", "),
+	)
+}
+
+// quote quotes string for using in shell with given style
+func quote(data, style string) string {
+	if data != "" && !needsQuoting(data, style) {
+		return data
+	}
+
+	switch style {
+	case QUOTE_BASH:
+		if hasControlChars(data) {
+			return quoteANSI(data)
+		}
+	case QUOTE_FISH:
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(data) + "'"
+	case QUOTE_POWERSHELL:
+		return "'" + strings.NewReplacer(
+			"'", "''", "‘", "‘‘", "’", "’’", "‚", "‚‚", "‛", "‛‛",
+		).Replace(data) + "'"
+	}
+
+	return "'" + strings.ReplaceAll(data, "'", `'\''`) + "'"
+}
+
+// quoteANSI quotes string using ANSI-C quoting ($'…')
+func quoteANSI(data string) string {
+	var buf strings.Builder
+
+	buf.WriteString("$'")
+
+	for i := 0; i < len(data); {
+		r, size := utf8.DecodeRuneInString(data[i:])
+
+		switch {
+		case r == utf8.RuneError && size == 1:
+			fmt.Fprintf(&buf, `\x%02x`, data[i])
+		case ansiEscapes[r] != "":
+			buf.WriteString(ansiEscapes[r])
+		case r < 0x80 && !unicode.IsPrint(r):
+			fmt.Fprintf(&buf, `\x%02x`, r)
+		case r < 0x10000 && !unicode.IsPrint(r):
+			fmt.Fprintf(&buf, `\u%04x`, r)
+		case !unicode.IsPrint(r):
+			fmt.Fprintf(&buf, `\U%08x`, r)
+		default:
+			buf.WriteRune(r)
+		}
+
+		i += size
+	}
+
+	buf.WriteString("'")
+
+	return buf.String()
+}
+
+// needsQuoting returns true if string contains symbols with special meaning
+// for shell with given style
+func needsQuoting(data, style string) bool {
+	safe := "@%+=:,./_-"
+
+	// Comma is array operator and @ is used for splatting and here-strings in
+	// PowerShell, leading dash makes parameter from argument
+	if style == QUOTE_POWERSHELL {
+		safe = "+=:./_-"
+
+		if strings.HasPrefix(data, "-") {
+			return true
+		}
+	}
+
+	for _, r := range data {
+		if !isASCIIAlnum(r) && !strings.ContainsRune(safe, r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasControlChars returns true if string contains control or invalid symbols
+func hasControlChars(data string) bool {
+	if !utf8.ValidString(data) {
+		return true
+	}
+
+	for _, r := range data {
+		if !unicode.IsPrint(r) && r != ' ' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// unquote removes shell quoting from string using rules of given style
+func unquote(data, style string) (string, error) {
+	var buf strings.Builder
+
+	for i := 0; i < len(data); {
+		var err error
+
+		switch {
+		case style == QUOTE_POWERSHELL && data[i] == '\'':
+			i, err = unquoteSingle(data, i+1, &buf, "''")
+		case style == QUOTE_POWERSHELL && data[i] == '"':
+			i, err = unquoteDouble(data, i+1, &buf, style)
+		case style == QUOTE_POWERSHELL && data[i] == '`' && i+1 < len(data):
+			buf.WriteByte(data[i+1])
+			i += 2
+		case style == QUOTE_POWERSHELL:
+			buf.WriteByte(data[i])
+			i++
+		case strings.HasPrefix(data[i:], "$'") && style != QUOTE_FISH:
+			i, err = unquoteANSI(data, i+2, &buf)
+		case data[i] == '\'' && style == QUOTE_FISH:
+			i, err = unquoteSingle(data, i+1, &buf, `\`)
+		case data[i] == '\'':
+			i, err = unquoteSingle(data, i+1, &buf, "")
+		case data[i] == '"':
+			i, err = unquoteDouble(data, i+1, &buf, style)
+		case data[i] == '\\' && i+1 < len(data):
+			buf.WriteByte(data[i+1])
+			i += 2
+		default:
+			buf.WriteByte(data[i])
+			i++
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// unquoteSingle unquotes single-quoted part of string and returns index of the
+// next symbol after closing quote. Escape defines how quote can be escaped in
+// quoted string: empty escape means that quote can't be escaped, two quotes mean
+// that quote is escaped by doubling and backslash means that quote is escaped by
+// backslash.
+func unquoteSingle(data string, i int, buf *strings.Builder, escape string) (int, error) {
+	for ; i < len(data); i++ {
+		switch {
+		case escape == `\` && data[i] == '\\' && i+1 < len(data) && strings.ContainsRune(`\'`, rune(data[i+1])):
+			buf.WriteByte(data[i+1])
+			i++
+		case escape == "''" && strings.HasPrefix(data[i:], "''"):
+			buf.WriteByte('\'')
+			i++
+		case data[i] == '\'':
+			return i + 1, nil
+		default:
+			buf.WriteByte(data[i])
+		}
+	}
+
+	return 0, fmt.Errorf("Unterminated single-quoted string")
+}
+
+// unquoteDouble unquotes double-quoted part of string and returns index of the
+// next symbol after closing quote. In POSIX shells backslash escapes only some
+// symbols and is kept as is before others, in PowerShell backtick escapes any
+// symbol.
+func unquoteDouble(data string, i int, buf *strings.Builder, style string) (int, error) {
+	escape, escapable := byte('\\'), "$`\"\\"
+
+	switch style {
+	case QUOTE_FISH:
+		escapable = "$\"\\"
+	case QUOTE_POWERSHELL:
+		escape = '`'
+	}
+
+	for ; i < len(data); i++ {
+		isEscape := data[i] == escape && i+1 < len(data)
+
+		switch {
+		case isEscape && style != QUOTE_POWERSHELL && data[i+1] == '\n':
+			// Escaped newline is line continuation
+			i++
+		case isEscape && (style == QUOTE_POWERSHELL || strings.IndexByte(escapable, data[i+1]) != -1):
+			buf.WriteByte(data[i+1])
+			i++
+		case style == QUOTE_POWERSHELL && strings.HasPrefix(data[i:], `""`):
+			buf.WriteByte('"')
+			i++
+		case data[i] == '"':
+			return i + 1, nil
+		default:
+			buf.WriteByte(data[i])
+		}
+	}
+
+	return 0, fmt.Errorf("Unterminated double-quoted string")
+}
+
+// unquoteANSI unquotes ANSI-C quoted ($'…') part of string and returns index of
+// the next symbol after closing quote
+func unquoteANSI(data string, i int, buf *strings.Builder) (int, error) {
+	for ; i < len(data); i++ {
+		if data[i] == '\'' {
+			return i + 1, nil
+		}
+
+		if data[i] != '\\' || i+1 >= len(data) {
+			buf.WriteByte(data[i])
+			continue
+		}
+
+		i++
+
+		switch data[i] {
+		case 'a':
+			buf.WriteByte('\a')
+		case 'b':
+			buf.WriteByte('\b')
+		case 'e', 'E':
+			buf.WriteByte(0x1B)
+		case 'f':
+			buf.WriteByte('\f')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 't':
+			buf.WriteByte('\t')
+		case 'v':
+			buf.WriteByte('\v')
+		case 'x', 'u', 'U', '0', '1', '2', '3', '4', '5', '6', '7':
+			size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[data[i]]
+			base, start := 16, i+1
+
+			if size == 0 {
+				base, size, start = 8, 3, i
+			}
+
+			end := start
+
+			for end < len(data) && end-start < size && isDigitOf(data[end], base) {
+				end++
+			}
+
+			code, err := strconv.ParseUint(data[start:end], base, 32)
+
+			if err != nil {
+				return 0, fmt.Errorf("Invalid escape sequence %q", data[i-1:end])
+			}
+
+			if data[i] == 'u' || data[i] == 'U' {
+				buf.WriteRune(rune(code))
+			} else {
+				buf.WriteByte(byte(code))
+			}
+
+			i = end - 1
+		default:
+			buf.WriteByte(data[i])
+		}
+	}
+
+	return 0, fmt.Errorf("Unterminated ANSI-C quoted string")
+}
+
+// isDigitOf returns true if symbol is digit in given base
+func isDigitOf(c byte, base int) bool {
+	switch {
+	case c >= '0' && c <= '7':
+		return true
+	case c == '8' || c == '9':
+		return base == 16
+	case (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F'):
+		return base == 16
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
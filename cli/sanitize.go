@@ -0,0 +1,291 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Sanitization profiles
+const (
+	PROFILE_POSIX   = "posix-portable"
+	PROFILE_WINDOWS = "windows"
+	PROFILE_FAT32   = "fat32"
+	PROFILE_S3      = "s3"
+)
+
+// SANITIZE_REPLACEMENT is replacement for invalid symbols
+const SANITIZE_REPLACEMENT = '_'
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// profile contains rules for file names on target system
+type profile struct {
+	IsValidRune   func(r rune) bool // Validator for name symbols
+	ReservedNames []string          // Reserved names (case-insensitive, without extension)
+	NoLeading     string            // Symbols which can't be used at the start of name
+	NoTrailing    string            // Symbols which can't be used at the end of name
+	MaxNameLen    int               // Maximum length of name
+	MaxPathLen    int               // Maximum length of path
+	IsUTF16       bool              // Lengths are measured in UTF-16 code units instead of bytes
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// windowsReservedNames contains reserved device names on Windows
+var windowsReservedNames = []string{
+	"CON", "PRN", "AUX", "NUL",
+	"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+	"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9",
+}
+
+// profiles contains all supported sanitization profiles
+var profiles = map[string]*profile{
+	PROFILE_POSIX: {
+		IsValidRune: func(r rune) bool {
+			return isASCIIAlnum(r) || strings.ContainsRune("._-", r)
+		},
+		NoLeading:  "-",
+		MaxNameLen: 255,
+		MaxPathLen: 4096,
+	},
+	PROFILE_WINDOWS: {
+		IsValidRune: func(r rune) bool {
+			return r >= 32 && !strings.ContainsRune(`<>:"\|?*`, r)
+		},
+		ReservedNames: windowsReservedNames,
+		NoTrailing:    ". ",
+		MaxNameLen:    255,
+		MaxPathLen:    260,
+		IsUTF16:       true,
+	},
+	PROFILE_FAT32: {
+		IsValidRune: func(r rune) bool {
+			return r >= 32 && r != 127 && !strings.ContainsRune(`<>:"\|?*`, r)
+		},
+		ReservedNames: windowsReservedNames,
+		NoTrailing:    ". ",
+		MaxNameLen:    255,
+		MaxPathLen:    260,
+		IsUTF16:       true,
+	},
+	PROFILE_S3: {
+		IsValidRune: func(r rune) bool {
+			return isASCIIAlnum(r) || strings.ContainsRune("!-_.*'()", r)
+		},
+		MaxNameLen: 1024,
+		MaxPathLen: 1024,
+	},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getProfile returns sanitization profile with given name
+func getProfile(name string) (*profile, error) {
+	p, ok := profiles[strings.ToLower(name)]
+
+	if !ok {
+		return nil, fmt.Errorf(
+			"Unknown profile %q (supported profiles: %s)",
+			name, strings.Join(getProfileNames(), ", "),
+		)
+	}
+
+	return p, nil
+}
+
+// getProfileNames returns sorted names of all supported profiles
+func getProfileNames() []string {
+	var result []string
+
+	for name := range profiles {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Sanitize returns path with all path elements converted to valid names
+func (p *profile) Sanitize(data string) string {
+	elems := strings.Split(data, "/")
+
+	for i, elem := range elems {
+		if elem != "" && elem != "." && elem != ".." {
+			elems[i] = p.sanitizeName(elem)
+		}
+	}
+
+	return strings.Join(elems, "/")
+}
+
+// Check checks path and returns description of the first violated rule
+func (p *profile) Check(data string) string {
+	if p.strLen(data) > p.MaxPathLen {
+		return fmt.Sprintf("path is longer than %d %s", p.MaxPathLen, p.lenUnit())
+	}
+
+	for _, elem := range strings.Split(data, "/") {
+		if elem == "" || elem == "." || elem == ".." {
+			continue
+		}
+
+		problem := p.checkName(elem)
+
+		if problem != "" {
+			return fmt.Sprintf("%q: %s", elem, problem)
+		}
+	}
+
+	return ""
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sanitizeName converts path element to valid name
+func (p *profile) sanitizeName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || !p.IsValidRune(r) {
+			return SANITIZE_REPLACEMENT
+		}
+
+		return r
+	}, name)
+
+	if p.isReservedName(name) {
+		stem, ext, _ := strings.Cut(name, ".")
+		name = stem + string(SANITIZE_REPLACEMENT) + strings.TrimSuffix("."+ext, ".")
+	}
+
+	if p.NoLeading != "" && strings.ContainsAny(name[:1], p.NoLeading) {
+		name = string(SANITIZE_REPLACEMENT) + name[1:]
+	}
+
+	if p.strLen(name) > p.MaxNameLen {
+		name = p.truncateName(name, p.MaxNameLen)
+	}
+
+	if p.NoTrailing != "" {
+		name = strings.TrimRight(name, p.NoTrailing)
+
+		if name == "" {
+			name = string(SANITIZE_REPLACEMENT)
+		}
+	}
+
+	return name
+}
+
+// checkName returns description of the first rule violated by path element
+func (p *profile) checkName(name string) string {
+	for _, r := range name {
+		if r == utf8.RuneError || !p.IsValidRune(r) {
+			return fmt.Sprintf("contains invalid symbol %q", r)
+		}
+	}
+
+	switch {
+	case p.isReservedName(name):
+		return "reserved name"
+	case p.NoLeading != "" && strings.ContainsAny(name[:1], p.NoLeading):
+		return fmt.Sprintf("starts with %q", name[:1])
+	case p.NoTrailing != "" && strings.ContainsAny(name[len(name)-1:], p.NoTrailing):
+		return fmt.Sprintf("ends with %q", name[len(name)-1:])
+	case p.strLen(name) > p.MaxNameLen:
+		return fmt.Sprintf("name is longer than %d %s", p.MaxNameLen, p.lenUnit())
+	}
+
+	return ""
+}
+
+// isReservedName returns true if name (without extension) is reserved
+func (p *profile) isReservedName(name string) bool {
+	stem, _, _ := strings.Cut(name, ".")
+	return slices.Contains(p.ReservedNames, strings.ToUpper(strings.TrimRight(stem, " ")))
+}
+
+// truncateName truncates name to given length keeping extension
+func (p *profile) truncateName(name string, size int) string {
+	stem, ext := splitExt(name)
+
+	if p.strLen(ext) >= size {
+		stem, ext = name, ""
+	}
+
+	size -= p.strLen(ext)
+
+	if p.IsUTF16 {
+		return truncateUTF16(stem, size) + ext
+	}
+
+	return truncateUTF8(stem, size) + ext
+}
+
+// strLen returns length of string in units used by profile
+func (p *profile) strLen(data string) int {
+	if p.IsUTF16 {
+		return utf16Len(data)
+	}
+
+	return len(data)
+}
+
+// lenUnit returns name of length units used by profile
+func (p *profile) lenUnit() string {
+	if p.IsUTF16 {
+		return "UTF-16 code units"
+	}
+
+	return "bytes"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// truncateUTF8 truncates string to given length in bytes on UTF-8 boundary
+func truncateUTF8(data string, size int) string {
+	if len(data) <= size {
+		return data
+	}
+
+	for size > 0 && !utf8.RuneStart(data[size]) {
+		size--
+	}
+
+	return data[:size]
+}
+
+// truncateUTF16 truncates string to given length in UTF-16 code units on rune
+// boundary
+func truncateUTF16(data string, size int) string {
+	for i, r := range data {
+		size -= utf16.RuneLen(r)
+
+		if size < 0 {
+			return data[:i]
+		}
+	}
+
+	return data
+}
+
+// isASCIIAlnum returns true if rune is ASCII letter or digit
+func isASCIIAlnum(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
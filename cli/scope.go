@@ -0,0 +1,135 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/options"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Scopes of path
+const (
+	SCOPE_BASE = "base"
+	SCOPE_STEM = "stem"
+	SCOPE_DIR  = "dir"
+	SCOPE_EXT  = "ext"
+)
+
+// SCOPE_PREFIX is prefix of scope modifier
+const SCOPE_PREFIX = "@"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// scopes contains all supported scopes
+var scopes = []string{SCOPE_BASE, SCOPE_STEM, SCOPE_DIR, SCOPE_EXT}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isScopedCommand returns true if command has scope modifier
+func isScopedCommand(cmd string) bool {
+	return strings.HasPrefix(cmd, SCOPE_PREFIX)
+}
+
+// newScopeHandler creates handler which executes command only for part of path
+// defined by scope modifier (e.g. @stem:add-suffix)
+func newScopeHandler(cmd string, args options.Arguments) (*handler, []string, error) {
+	scope, name, ok := strings.Cut(strings.TrimPrefix(cmd, SCOPE_PREFIX), ":")
+	scope = strings.ToLower(scope)
+
+	// Nested command can be passed as the first argument (e.g. @stem: lower)
+	if ok && name == "" && len(args) != 0 {
+		name, args = args.Get(0).String(), args[1:]
+	}
+
+	if !ok || name == "" {
+		return nil, nil, usageError("Invalid scope modifier %q (must be in @scope:command format)", cmd)
+	}
+
+	if !slices.Contains(scopes, scope) {
+		return nil, nil, usageError(
+			"Unknown scope %q (supported scopes: %s)",
+			scope, strings.Join(scopes, ", "),
+		)
+	}
+
+	name = getCommandName(name)
+
+	if multiCommands[name] || pairCommands[name] {
+		return nil, nil, usageError("Command %q can't be used with scope modifier", name)
+	}
+
+	nested, extra, err := createCommandHandler(name, args)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isPredicate := getCommandInfo(name) != nil && getCommandInfo(name).Group == GROUP_PREDICATES
+
+	fn := nestedHandlerFunc(nested, func(h *handler, data string) (string, error, bool) {
+		return executeScopeHandler(h, scope, data, isPredicate)
+	})
+
+	return &handler{fn, nil, SCOPE_PREFIX + scope + ":" + name}, extra, nil
+}
+
+// executeScopeHandler executes handler for part of path and assembles path back
+func executeScopeHandler(h *handler, scope, data string, isPredicate bool) (string, error, bool) {
+	dir, base, trailing := splitPath(data)
+	stem, ext := splitExt(base)
+
+	var part *string
+
+	switch scope {
+	case SCOPE_BASE:
+		part = &base
+	case SCOPE_STEM:
+		part = &stem
+	case SCOPE_EXT:
+		part = &ext
+	case SCOPE_DIR:
+		part = &dir
+	}
+
+	if *part == "" && !isPredicate {
+		return data, nil, true
+	}
+
+	value := *part
+
+	if scope == SCOPE_DIR && value != "/" {
+		value = strings.TrimRight(value, "/")
+	}
+
+	value, err, ok := h.Func(value, h.Args)
+
+	if err != nil || !ok || isPredicate {
+		return "", err, ok
+	}
+
+	switch scope {
+	case SCOPE_BASE:
+		return dir + value + trailing, nil, true
+	case SCOPE_DIR:
+		if value != "" && !strings.HasSuffix(value, "/") {
+			value += "/"
+		}
+
+		return value + base + trailing, nil, true
+	}
+
+	*part = value
+
+	return dir + stem + ext + trailing, nil, true
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
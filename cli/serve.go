@@ -0,0 +1,237 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/essentialkaos/ek/v13/options"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Server response statuses
+const (
+	RESP_OK    = "OK"
+	RESP_FALSE = "FALSE"
+	RESP_ERROR = "ERROR"
+)
+
+// PIPE_CACHE_SIZE is maximum number of parsed pipelines kept in cache
+const PIPE_CACHE_SIZE = 64
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// server is coprocess server
+type server struct {
+	pipes map[string]*list.Element // Cache with parsed pipelines
+	lru   *list.List               // Cached pipelines ordered by last use
+	mu    *sync.Mutex
+}
+
+// cachedPipe contains parsed pipeline and its source
+type cachedPipe struct {
+	Pipeline string
+	Pipe     pipe
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startServer starts coprocess server which reads requests from stdin or
+// Unix socket
+func startServer(socket string) int {
+	// Records framed by space can't contain paths with spaces
+	if separator == " " {
+		printError("Option %s can't be used in coprocess mode", options.F(OPT_SPACE))
+		return EC_USAGE
+	}
+
+	srv := &server{pipes: map[string]*list.Element{}, lru: list.New(), mu: &sync.Mutex{}}
+
+	defer stopPlugins()
+
+	if socket == "" || socket == "true" {
+		err := srv.Serve(os.Stdin, os.Stdout)
+
+		if err != nil {
+			printError(err.Error())
+			return EC_IO
+		}
+
+		return EC_OK
+	}
+
+	err := srv.Listen(socket)
+
+	if err != nil {
+		printError(err.Error())
+		return EC_IO
+	}
+
+	return EC_OK
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Listen listens Unix socket and serves all incoming connections
+func (s *server) Listen(socket string) error {
+	listener, err := net.Listen("unix", socket)
+
+	if err != nil {
+		return fmt.Errorf("Can't listen socket: %v", err)
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigs
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+
+			return fmt.Errorf("Can't accept connection: %v", err)
+		}
+
+		go func() {
+			s.Serve(conn, conn)
+			conn.Close()
+		}()
+	}
+}
+
+// Serve reads requests from given reader and writes responses to given writer
+func (s *server) Serve(r io.Reader, w io.Writer) error {
+	rr := bufio.NewReader(r)
+	ww := bufio.NewWriter(w)
+	delim := separator[0]
+
+	for {
+		req, err := rr.ReadString(delim)
+
+		if err != nil && (err != io.EOF || req == "") {
+			if err == io.EOF {
+				return nil
+			}
+
+			return fmt.Errorf("Can't read request: %v", err)
+		}
+
+		s.handleRequest(ww, strings.TrimSuffix(req, separator))
+
+		flushErr := ww.Flush()
+
+		if flushErr != nil {
+			return fmt.Errorf("Can't write response: %v", flushErr)
+		}
+
+		// The last request without trailing delimiter
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// handleRequest processes request and writes response
+func (s *server) handleRequest(w *bufio.Writer, req string) {
+	pipeline, data, ok := strings.Cut(req, "\t")
+
+	if !ok {
+		s.writeResponse(w, RESP_ERROR, "Request must contain pipeline and data separated by tab")
+		return
+	}
+
+	if pairMode {
+		var err error
+
+		data, err = parsePair(data)
+
+		if err != nil {
+			s.writeResponse(w, RESP_ERROR, err.Error())
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.getPipe(pipeline)
+
+	if err != nil {
+		s.writeResponse(w, RESP_ERROR, err.Error())
+		return
+	}
+
+	result, err, ok := executePipeHandlers(p, data)
+
+	switch {
+	case err != nil:
+		s.writeResponse(w, RESP_ERROR, err.Error())
+	case !ok:
+		s.writeResponse(w, RESP_FALSE, "0")
+	default:
+		s.writeResponse(w, RESP_OK, strconv.Itoa(len(result)))
+
+		// Records are always escaped, so they can't contain delimiter
+		for _, item := range result {
+			w.WriteString(escapeRecord(item) + separator)
+		}
+	}
+}
+
+// getPipe returns parsed pipeline from cache or parses it. The least recently
+// used pipeline is removed from cache if cache is full.
+func (s *server) getPipe(pipeline string) (pipe, error) {
+	item, ok := s.pipes[pipeline]
+
+	if ok {
+		s.lru.MoveToFront(item)
+		return item.Value.(*cachedPipe).Pipe, nil
+	}
+
+	p, err := parseCommandPipe(pipeline)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.pipes[pipeline] = s.lru.PushFront(&cachedPipe{pipeline, p})
+
+	if s.lru.Len() > PIPE_CACHE_SIZE {
+		delete(s.pipes, s.lru.Remove(s.lru.Back()).(*cachedPipe).Pipeline)
+	}
+
+	return p, nil
+}
+
+// writeResponse writes response header
+func (s *server) writeResponse(w *bufio.Writer, status, info string) {
+	w.WriteString(status + " " + strings.ReplaceAll(info, separator, " ") + separator)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
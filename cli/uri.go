@@ -0,0 +1,200 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// URI_SCHEME_FILE is scheme of URI for local files
+const URI_SCHEME_FILE = "file"
+
+// Path modes
+const (
+	MODE_PATH = "path"
+	MODE_URL  = "url"
+	MODE_AUTO = "auto"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// winDriveRegex is regexp for Windows drive letter in URI path
+var winDriveRegex = regexp.MustCompile(`^/[A-Za-z]:(/|$)`)
+
+// urlRegex is regexp for splitting URL into scheme with host, path and query
+// with fragment. Scheme must contain at least two symbols, so Windows paths with
+// drive letter (C://dir) are not treated as URLs.
+var urlRegex = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9+.-]+://[^/?#]*)([^?#]*)(.*)$`)
+
+// urlCommands contains commands which can work with URL path component. Value
+// is true if command returns path, so scheme, host, query and fragment must be
+// preserved.
+var urlCommands = map[string]bool{
+	CMD_BASENAME:    false,
+	CMD_EXT:         false,
+	CMD_STEM:        false,
+	CMD_DIRNAME:     true,
+	CMD_DIRNAME_NUM: true,
+	CMD_CLEAN:       true,
+	CMD_STRIP_EXT:   true,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// pathToURI converts path to file URI. Leading //server of UNC path is used as
+// URI host.
+func pathToURI(data string) string {
+	host, rest, ok := splitUNCPath(data)
+
+	if ok {
+		u := &url.URL{Scheme: URI_SCHEME_FILE, Host: host, Path: path.Clean("/" + rest)}
+
+		if strings.HasSuffix(rest, "/") && u.Path != "/" {
+			u.Path += "/"
+		}
+
+		return u.String()
+	}
+
+	p := absPath(data)
+
+	if strings.HasSuffix(data, "/") && !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+
+	return (&url.URL{Scheme: URI_SCHEME_FILE, Path: p}).String()
+}
+
+// splitUNCPath splits UNC path (//server/share/…) into server name and path
+// on server
+func splitUNCPath(data string) (string, string, bool) {
+	if !strings.HasPrefix(data, "//") || strings.HasPrefix(data, "///") {
+		return "", "", false
+	}
+
+	host, rest, _ := strings.Cut(data[2:], "/")
+
+	return host, rest, host != ""
+}
+
+// uriToPath converts file URI to path
+func uriToPath(data string) (string, error) {
+	u, err := url.Parse(data)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't parse URI: %v", err)
+	}
+
+	if !strings.EqualFold(u.Scheme, URI_SCHEME_FILE) {
+		if u.Scheme == "" {
+			return "", fmt.Errorf("URI doesn't have scheme (only file URIs are supported)")
+		}
+
+		return "", fmt.Errorf("Unsupported URI scheme %q (only file URIs are supported)", u.Scheme)
+	}
+
+	if u.Opaque != "" {
+		return "", fmt.Errorf("URI doesn't contain absolute path")
+	}
+
+	// Encoded slash can't be a part of path element
+	if strings.Contains(strings.ToUpper(u.EscapedPath()), "%2F") {
+		return "", fmt.Errorf("URI contains encoded slash (%%2F) in path element")
+	}
+
+	switch {
+	case u.Host != "" && !strings.EqualFold(u.Host, "localhost"):
+		return "//" + u.Host + u.Path, nil
+	case winDriveRegex.MatchString(u.Path):
+		return u.Path[1:], nil
+	}
+
+	return u.Path, nil
+}
+
+// urlEncode encodes every path element using percent-encoding
+func urlEncode(data string) string {
+	elems := strings.Split(data, "/")
+
+	for i, elem := range elems {
+		elems[i] = url.PathEscape(elem)
+	}
+
+	return strings.Join(elems, "/")
+}
+
+// urlDecode decodes every path element. Encoded slashes (%2F) are kept as is,
+// because they are a part of path element (or returns error in strict mode).
+func urlDecode(data string) (string, error) {
+	elems := strings.Split(data, "/")
+
+	for i, elem := range elems {
+		elem, err := url.PathUnescape(elem)
+
+		if err != nil {
+			return "", err
+		}
+
+		if strings.Contains(elem, "/") {
+			if strictMode {
+				return "", fmt.Errorf("element %q contains encoded slash (%%2F)", elems[i])
+			}
+
+			elem = strings.ReplaceAll(elem, "/", "%2F")
+		}
+
+		elems[i] = elem
+	}
+
+	return strings.Join(elems, "/"), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isURLRecord returns true if record must be processed as URL
+func isURLRecord(data string) bool {
+	switch pathMode {
+	case MODE_URL:
+		return true
+	case MODE_AUTO:
+		return urlRegex.MatchString(data)
+	}
+
+	return false
+}
+
+// executeURLHandler executes handler for path component of URL
+func executeURLHandler(h *handler, data string) (string, error, bool) {
+	parts := urlRegex.FindStringSubmatch(data)
+
+	if parts == nil {
+		return "", fmt.Errorf("Record is not a valid URL"), false
+	}
+
+	prefix, urlPath, suffix := parts[1], parts[2], parts[3]
+
+	if urlPath == "" {
+		urlPath = "/"
+	}
+
+	result, err, ok := h.Func(urlPath, h.Args)
+
+	if err != nil || !ok || result == "" || !urlCommands[h.Name] {
+		return result, err, ok
+	}
+
+	return prefix + result + suffix, nil, true
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
@@ -0,0 +1,286 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/strutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Path styles
+const (
+	STYLE_POSIX   = "posix"
+	STYLE_WINDOWS = "windows"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// driveRegex is regexp for drive letter in Windows, WSL and Cygwin paths
+var driveRegex = regexp.MustCompile(`^(?:([A-Za-z]):|/mnt/([a-z])(?:/|$)|/cygdrive/([a-z])(?:/|$))`)
+
+// winCommands contains commands which support Windows path style. Value is true
+// if command returns path, so volume name must be preserved.
+var winCommands = map[string]bool{
+	CMD_BASENAME:    false,
+	CMD_EXT:         false,
+	CMD_STEM:        false,
+	CMD_SPLIT:       false,
+	CMD_ELEM:        false,
+	CMD_DEPTH:       false,
+	CMD_IS_ABS:      false,
+	CMD_MATCH:       false,
+	CMD_IS_MATCH:    false,
+	CMD_IS_LOCAL:    false,
+	CMD_IS_PORTABLE: false,
+	CMD_IS_WITHIN:   false,
+	CMD_DIRNAME:     true,
+	CMD_DIRNAME_NUM: true,
+	CMD_CLEAN:       true,
+	CMD_STRIP_EXT:   true,
+	CMD_SLICE:       true,
+	CMD_SET_EXT:     true,
+	CMD_SET_BASE:    true,
+	CMD_SNAKE:       true,
+	CMD_KEBAB:       true,
+	CMD_CAMEL:       true,
+	CMD_PASCAL:      true,
+	CMD_TITLE:       true,
+	CMD_SLUG:        true,
+	CMD_SANITIZE:    true,
+	CMD_EACH:        true,
+	CMD_EACH_DIR:    true,
+	CMD_EACH_BASE:   true,
+
+	CMD_MAX_NAME_LEN: true,
+	CMD_MAX_DEPTH:    true,
+}
+
+// winUnsupportedCommands contains commands which can't work with Windows paths
+var winUnsupportedCommands = map[string]bool{
+	CMD_READLINK:  true,
+	CMD_ABS:       true,
+	CMD_EXPAND:    true,
+	CMD_TILDE:     true,
+	CMD_JOIN:      true,
+	CMD_REL:       true,
+	CMD_REL_REAL:  true,
+	CMD_REL_UNDER: true,
+	CMD_SET_DIR:   true,
+	CMD_COMPACT:   true,
+	CMD_TRUNCATE:  true,
+	CMD_TO_URI:    true,
+	CMD_IS_SAFE:   true,
+	CMD_SAME_FILE: true,
+	CMD_COMMON:    true,
+	CMD_IS_UNDER:  true,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isWinCommand returns true if command supports Windows path style. Commands
+// with scope modifiers return path.
+func isWinCommand(name string) bool {
+	_, ok := winCommands[name]
+	return ok || isScopedCommand(name)
+}
+
+// isWinPathCommand returns true if command with Windows path style support
+// returns path
+func isWinPathCommand(name string) bool {
+	return winCommands[name] || isScopedCommand(name)
+}
+
+// executeWinHandler executes handler for path with Windows path rules
+func executeWinHandler(h *handler, data string) (string, error, bool) {
+	vol, rest := splitWinVolume(data)
+
+	switch h.Name {
+	case CMD_IS_ABS:
+		return "", nil, isWinAbs(vol, rest)
+	case CMD_IS_LOCAL:
+		return "", nil, vol == "" && rest != "" && filepath.IsLocal(rest)
+	case CMD_IS_WITHIN:
+		// Limits are checked for the full path including volume name
+		return h.Func(strings.ReplaceAll(data, `\`, "/"), h.Args)
+	case CMD_MATCH, CMD_IS_MATCH:
+		isMatch, err := path.Match(winPattern(h.Args.Get(0).String()), winPattern(data))
+
+		if err != nil && strictMode {
+			return "", fmt.Errorf("Invalid pattern %q: %v", h.Args.Get(0).String(), err), false
+		}
+
+		if h.Name == CMD_IS_MATCH {
+			return "", nil, isMatch
+		}
+
+		return strutil.B(isMatch, data, ""), nil, true
+	}
+
+	if rest == "" {
+		rest = "."
+	}
+
+	result, err, ok := h.Func(rest, h.Args)
+
+	if err != nil || !ok || result == "" {
+		return result, err, ok
+	}
+
+	// Commands which return path element can return only root directory
+	// with separator (e.g. base of C:\)
+	if !isWinPathCommand(h.Name) {
+		return strings.ReplaceAll(result, "/", `\`), nil, true
+	}
+
+	// Relative path on drive (C:file.txt) keeps volume name
+	if vol != "" && (strings.HasPrefix(result, "/") || !strings.HasPrefix(rest, "/")) {
+		result = vol + result
+	}
+
+	return strings.ReplaceAll(result, "/", `\`), nil, true
+}
+
+// winPattern converts Windows path or pattern to lower case with forward slashes
+// for case-insensitive matching
+func winPattern(data string) string {
+	return strings.ToLower(strings.ReplaceAll(data, `\`, "/"))
+}
+
+// splitWinVolume splits Windows path into volume name and path with forward
+// slashes
+func splitWinVolume(data string) (string, string) {
+	p := strings.ReplaceAll(data, `\`, "/")
+	size := winVolumeLen(p)
+
+	return data[:size], p[size:]
+}
+
+// winVolumeLen returns length of volume name in Windows path with forward slashes
+func winVolumeLen(p string) int {
+	switch {
+	case len(p) >= 2 && isASCIILetter(p[0]) && p[1] == ':':
+		return 2
+
+	case strings.HasPrefix(p, "//?/") || strings.HasPrefix(p, "//./"):
+		rest := p[4:]
+
+		switch {
+		case len(rest) >= 4 && strings.EqualFold(rest[:4], "UNC/"):
+			return 8 + uncLen(rest[4:])
+		case len(rest) >= 2 && isASCIILetter(rest[0]) && rest[1] == ':':
+			return 6
+		case strings.Contains(rest, "/"):
+			return 4 + strings.IndexByte(rest, '/')
+		}
+
+		return len(p)
+
+	case len(p) > 2 && strings.HasPrefix(p, "//") && p[2] != '/':
+		return 2 + uncLen(p[2:])
+	}
+
+	return 0
+}
+
+// uncLen returns length of server and share names in UNC path
+func uncLen(p string) int {
+	server := strings.IndexByte(p, '/')
+
+	if server == -1 {
+		return len(p)
+	}
+
+	share := strings.IndexByte(p[server+1:], '/')
+
+	if share == -1 {
+		return len(p)
+	}
+
+	return server + 1 + share
+}
+
+// isWinAbs returns true if Windows path is absolute
+func isWinAbs(vol, rest string) bool {
+	switch {
+	case vol == "":
+		return false
+	case len(vol) == 2:
+		return strings.HasPrefix(rest, "/")
+	}
+
+	return true
+}
+
+// isASCIILetter returns true if symbol is ASCII letter
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// toPosixPath converts Windows path to path with forward slashes
+func toPosixPath(data string) string {
+	p := strings.ReplaceAll(data, `\`, "/")
+
+	switch {
+	case len(p) >= 8 && strings.EqualFold(p[:8], "//?/UNC/"):
+		return "//" + p[8:]
+	case strings.HasPrefix(p, "//?/"):
+		return p[4:]
+	}
+
+	return p
+}
+
+// toWinPath converts path to Windows path
+func toWinPath(data string) string {
+	p := toPosixPath(data)
+	drive, rest := splitDrive(p)
+
+	if drive == "" {
+		return strings.ReplaceAll(rest, "/", `\`)
+	}
+
+	// Relative path on drive (C:file.txt) doesn't have separator after drive
+	if p[1] == ':' && !strings.HasPrefix(p[2:], "/") {
+		return strings.ToUpper(drive) + ":" + strings.ReplaceAll(rest, "/", `\`)
+	}
+
+	return strings.ToUpper(drive) + ":" + strings.ReplaceAll("/"+rest, "/", `\`)
+}
+
+// toDrivePath converts path to path with drive mounted to given directory
+func toDrivePath(data, mountDir string) string {
+	drive, rest := splitDrive(toPosixPath(data))
+
+	if drive == "" {
+		return rest
+	}
+
+	return strings.TrimRight(mountDir+"/"+strings.ToLower(drive)+"/"+strings.TrimPrefix(rest, "/"), "/")
+}
+
+// splitDrive splits path into drive letter and path on drive
+func splitDrive(p string) (string, string) {
+	m := driveRegex.FindStringSubmatch(p)
+
+	if m == nil {
+		return "", p
+	}
+
+	return m[1] + m[2] + m[3], strings.TrimPrefix(p[len(m[0]):], "/")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
@@ -0,0 +1,228 @@
+//go:build cgo && !windows
+// +build cgo,!windows
+
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/terminal"
+	"github.com/essentialkaos/ek/v13/terminal/input"
+
+	"golang.org/x/sys/unix"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// MAX_SAMPLES is maximum number of data samples used in interactive mode
+const MAX_SAMPLES = 10
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var withInteractive = true
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startInteractive starts interactive mode for building pipelines
+func startInteractive(args options.Arguments) int {
+	samples, err := readSamples(args)
+
+	if err != nil {
+		printError(err.Error())
+		return EC_IO
+	}
+
+	if len(samples) == 0 {
+		printError("There is no data for interactive mode")
+		return EC_USAGE
+	}
+
+	// Data is read from stdin, so pipelines are read from the controlling terminal
+	if hasStdinData {
+		err = attachTTY()
+
+		if err != nil {
+			printError("%v (interactive mode requires terminal)", err)
+			return EC_USAGE
+		}
+	}
+
+	commands := getCommandNames()
+
+	input.Prompt = "{s}pipeline{!} {s-}›{!} "
+	input.SetCompletionHandler(func(data string) []string {
+		return completePipeline(data, commands)
+	})
+
+	fmtc.Println("{s-}Type pipeline and press Enter to see results. Press Enter on empty line or Ctrl+C to exit.{!}\n")
+
+	var final string
+
+	for {
+		pipeline, err := input.Read("")
+
+		if err != nil {
+			break
+		}
+
+		pipeline = strings.TrimSpace(pipeline)
+
+		if pipeline == "" {
+			break
+		}
+
+		input.AddHistory(pipeline)
+
+		p, err := parseCommandPipe(pipeline)
+
+		if err != nil {
+			terminal.Warn(err.Error())
+			fmtc.NewLine()
+			continue
+		}
+
+		printPreview(p, samples)
+		stopPlugins()
+
+		final = pipeline
+	}
+
+	if final != "" {
+		fmt.Printf("path '%s'\n", strings.ReplaceAll(final, "'", `'\''`))
+	}
+
+	return 0
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readSamples reads data samples from arguments and stdin
+func readSamples(args options.Arguments) ([]string, error) {
+	samples := args.Strings()
+
+	if len(samples) >= MAX_SAMPLES {
+		return samples[:MAX_SAMPLES], nil
+	}
+
+	if !hasStdinData {
+		return samples, nil
+	}
+
+	r := bufio.NewReader(os.Stdin)
+	delim := separator[0]
+
+	for len(samples) < MAX_SAMPLES {
+		str, err := r.ReadString(delim)
+
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("Can't read stdin data: %v", err)
+		}
+
+		str = strings.TrimRight(str, separator)
+
+		if str != "" {
+			samples = append(samples, str)
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	return samples, nil
+}
+
+// attachTTY replaces stdin with the controlling terminal
+func attachTTY() error {
+	fd, err := os.Open("/dev/tty")
+
+	if err != nil {
+		return fmt.Errorf("Can't open terminal: %v", err)
+	}
+
+	err = unix.Dup2(int(fd.Fd()), int(os.Stdin.Fd()))
+
+	if err != nil {
+		return fmt.Errorf("Can't attach terminal: %v", err)
+	}
+
+	return nil
+}
+
+// getCommandNames returns names of all supported commands
+func getCommandNames() []string {
+	var result []string
+
+	for _, cmd := range addPluginsUsage(genUsage()).Commands {
+		result = append(result, cmd.Name)
+	}
+
+	return result
+}
+
+// completePipeline returns completion variants for the last command in pipeline
+func completePipeline(data string, commands []string) []string {
+	var result []string
+
+	prefix, cmd := "", data
+
+	if strings.ContainsRune(data, ',') {
+		i := strings.LastIndexByte(data, ',')
+		prefix, cmd = data[:i+1], data[i+1:]
+	}
+
+	if strings.ContainsRune(cmd, '+') {
+		return nil
+	}
+
+	for _, name := range commands {
+		if strings.HasPrefix(name, cmd) {
+			result = append(result, prefix+name)
+		}
+	}
+
+	return result
+}
+
+// printPreview prints results of pipeline execution for all samples
+func printPreview(p pipe, samples []string) {
+	for _, sample := range samples {
+		result, err, ok := executePipeHandlers(p, sample)
+
+		fmtc.Printf("  {s-}%s{!} {s}→{!} ", formatOutput(sample, safeOutput))
+
+		switch {
+		case err != nil:
+			fmtc.Printfn("{r}%v{!}", err)
+		case !ok:
+			fmtc.Println("{y}false{!}")
+		case len(result) == 0:
+			fmtc.Println("{s-}—{!}")
+		default:
+			safe := isSafeOutput(p)
+
+			for i, item := range result {
+				result[i] = formatOutput(item, safe)
+			}
+
+			fmtc.Println(strings.Join(result, "{s-}, {!}"))
+		}
+	}
+
+	fmtc.NewLine()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
@@ -0,0 +1,26 @@
+//go:build !cgo || windows
+// +build !cgo windows
+
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2026 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import "github.com/essentialkaos/ek/v13/options"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var withInteractive = false
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// startInteractive starts interactive mode for building pipelines
+func startInteractive(args options.Arguments) int {
+	return 1
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
@@ -2,9 +2,13 @@ module github.com/essentialkaos/path
 
 go 1.24.11
 
-require github.com/essentialkaos/ek/v13 v13.38.3
+require (
+	github.com/essentialkaos/ek/v13 v13.38.3
+	golang.org/x/sys v0.40.0
+	golang.org/x/text v0.34.0
+)
 
 require (
 	github.com/essentialkaos/depsy v1.3.1 // indirect
-	golang.org/x/sys v0.40.0 // indirect
+	github.com/essentialkaos/go-linenoise/v3 v3.7.0 // indirect
 )